@@ -1,37 +1,400 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/command"
+	"github.com/friendly-social/cli/internal/demo"
+	"github.com/friendly-social/cli/internal/dirs"
 	"github.com/friendly-social/cli/internal/navigation"
 	"github.com/friendly-social/cli/internal/router"
 	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/accounts"
+	"github.com/friendly-social/cli/internal/screen/edit"
+	"github.com/friendly-social/cli/internal/screen/feed"
+	"github.com/friendly-social/cli/internal/screen/friend"
 	"github.com/friendly-social/cli/internal/screen/home"
+	"github.com/friendly-social/cli/internal/screen/intro"
+	"github.com/friendly-social/cli/internal/screen/network"
 	"github.com/friendly-social/cli/internal/screen/profile"
 	"github.com/friendly-social/cli/internal/screen/register"
+	"github.com/friendly-social/cli/internal/screen/requests"
+	"github.com/friendly-social/cli/internal/screen/token"
+	"github.com/friendly-social/cli/internal/transport"
+	"github.com/friendly-social/cli/internal/ui"
 	sdk "github.com/friendly-social/golang-sdk"
 )
 
+const (
+	defaultEndpoint = "https://api.getfriend.ly"
+	endpointEnv     = "FRIENDLY_ENDPOINT"
+)
+
+// apiClient unites methods required by all screen services.
+type apiClient interface {
+	command.SelfTestClient
+	edit.Client
+	feed.Client
+	friend.Client
+	network.Client
+	profile.Client
+	register.Client
+	requests.Client
+	token.Client
+}
+
+// sdkClient adapts sdk.Client to methods which aren't provided by it directly.
+type sdkClient struct {
+	*sdk.Client
+}
+
+// option returns EditAccount option built from value or nothing if value is nil.
+func option[V, O any](value *V, build func(V) O) []O {
+	if value == nil {
+		return nil
+	}
+
+	return []O{build(*value)}
+}
+
+// EditProfile sends only fields present in changes, so the rest stay untouched.
+func (c sdkClient) EditProfile(ctx context.Context, auth *sdk.Authorization, changes edit.Changes) error {
+	opts := slices.Concat(
+		option(changes.Nickname, sdk.EditNicknameOption),
+		option(changes.Description, sdk.EditDescriptionOption),
+		option(changes.Interests, sdk.EditInterestsOption))
+
+	return c.EditAccount(ctx, auth, opts...)
+}
+
+func logRequest(method, path string, status int, dur time.Duration) {
+	log.Printf("%s %s -> %d (%s)", method, path, status, dur)
+}
+
+// resolveEndpoint chooses URL of the server from localhost port, endpoint or default server in that order of precedence.
+func resolveEndpoint(endpoint string, port int) string {
+	if port != 0 {
+		return fmt.Sprintf("http://localhost:%d", port)
+	}
+
+	if endpoint == "" {
+		endpoint = os.Getenv(endpointEnv)
+	}
+
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+
+	return endpoint
+}
+
+// hostOf returns host part of endpoint or endpoint itself if it can't be parsed.
+func hostOf(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return endpoint
+	}
+
+	return u.Host
+}
+
+// headers collects repeatable --header flags in "Key: Value" form.
+type headers http.Header
+
+func (h headers) String() string {
+	return fmt.Sprint(http.Header(h))
+}
+
+func (h headers) Set(value string) error {
+	key, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("header must look like \"Key: Value\", got %q", value)
+	}
+
+	http.Header(h).Add(strings.TrimSpace(key), strings.TrimSpace(val))
+	return nil
+}
+
+// options holds values of command line flags.
+type options struct {
+	endpoint  string
+	port      int
+	verbose   bool
+	demo      bool
+	rateLimit float64
+	rateBurst int
+	export    string
+	debug     bool
+	headers   headers
+	poll      time.Duration
+	refresh   time.Duration
+	theme     string
+	timeout   time.Duration
+	json      bool
+	configDir string
+	profile   string
+	cache     time.Duration
+	proxy     func(*http.Request) (*url.URL, error)
+	proxySet  bool
+
+	maxIdleConns int
+	idleTimeout  time.Duration
+	noKeepAlive  bool
+}
+
+func parseOptions(args []string) options {
+	opts := options{headers: headers{}, proxy: http.ProxyFromEnvironment}
+	flags := flag.NewFlagSet("friendly", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: friendly [%s] [flags]\n", strings.Join(command.Names(), "|"))
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&opts.endpoint, "endpoint", "", "URL of Friendly server (overrides "+endpointEnv+")")
+	flags.IntVar(&opts.port, "port", 0, "port of Friendly server running on localhost (overrides --endpoint)")
+	flags.BoolVar(&opts.verbose, "verbose", false, "log every API request to debug.log in data dir, or to stderr when running a command")
+	flags.BoolVar(&opts.demo, "demo", false, "explore the app offline with canned data")
+	flags.Float64Var(&opts.rateLimit, "rate-limit", 0, "maximum API requests per second, 0 disables limiting")
+	flags.IntVar(&opts.rateBurst, "rate-burst", 1, "maximum burst of API requests allowed by --rate-limit")
+	flags.StringVar(&opts.export, "export", "", "path of the file profile data is exported to (default friendly-export.json in data dir)")
+	flags.BoolVar(&opts.debug, "debug", false, "enable ctrl+r pane showing raw data behind the current screen")
+	flags.DurationVar(&opts.poll, "poll", 30*time.Second, "interval of checking pending friend requests, 0 disables it")
+	flags.DurationVar(&opts.refresh, "auto-refresh", time.Minute, "base interval of feed auto refresh toggled with a, 0 disables it")
+	flags.StringVar(&opts.theme, "theme", "default", "color theme, one of: "+strings.Join(ui.Themes(), ", "))
+	flags.Var(opts.headers, "header", "extra \"Key: Value\" header sent with every API request, can be repeated")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "default timeout of an API request, uploads aren't limited by it, 0 disables it")
+	flags.StringVar(&opts.configDir, "config-dir", "", "directory for all files of the app (default $XDG_CONFIG_HOME/friendly and $XDG_DATA_HOME/friendly)")
+	flags.Func("proxy", "URL of HTTP or SOCKS5 proxy for API requests (default HTTP_PROXY, HTTPS_PROXY and NO_PROXY)", func(value string) error {
+		proxy, err := transport.Proxy(value)
+		opts.proxy, opts.proxySet = proxy, true
+		return err
+	})
+	flags.StringVar(&opts.profile, "profile", "", "name of the account to use, each one is saved separately and can be switched to from home screen")
+	flags.DurationVar(&opts.cache, "cache", 0, "how long feed, network and profile responses are reused, 0 disables caching")
+	flags.IntVar(&opts.maxIdleConns, "max-idle-conns", 100, "maximum idle connections kept for reuse, 0 means no limit")
+	flags.DurationVar(&opts.idleTimeout, "idle-timeout", 90*time.Second, "how long idle connection is kept before closing, 0 keeps it forever")
+	flags.BoolVar(&opts.noKeepAlive, "no-keep-alive", false, "open a new connection for every request, useful for debugging")
+	flags.BoolVar(&opts.json, "json", false, "print result of a command as JSON instead of a table")
+	_ = flags.Parse(args)
+
+	return opts
+}
+
+// validate rejects combinations of flags which can't be honored.
+func (o options) validate() error {
+	if o.demo && (o.endpoint != "" || o.port != 0 || o.verbose || o.rateLimit != 0 || len(o.headers) != 0 || o.proxySet) {
+		return fmt.Errorf("--demo works offline and can't be combined with --endpoint, --port, --verbose, --rate-limit, --header or --proxy")
+	}
+
+	if o.poll < 0 || o.refresh < 0 || o.timeout < 0 || o.idleTimeout < 0 || o.maxIdleConns < 0 || o.cache < 0 {
+		return fmt.Errorf("--poll, --auto-refresh, --timeout, --idle-timeout, --max-idle-conns and --cache must not be negative")
+	}
+
+	if o.rateLimit < 0 {
+		return fmt.Errorf("--rate-limit must not be negative")
+	}
+
+	if o.rateLimit > 0 && o.rateBurst < 1 {
+		return fmt.Errorf("--rate-burst must be at least 1 when --rate-limit is set")
+	}
+
+	return nil
+}
+
+// newBase creates transport which connects to the server, shared by sdk.Client and Ping so they reuse connections.
+func newBase(opts options) *http.Transport {
+	// defaults of the flags match http.DefaultTransport
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.MaxIdleConns = opts.maxIdleConns
+	base.IdleConnTimeout = opts.idleTimeout
+	base.DisableKeepAlives = opts.noKeepAlive
+	base.Proxy = opts.proxy
+	return base
+}
+
+// withHeaders adds headers of --header flags to requests of next.
+func withHeaders(next http.RoundTripper, opts options) http.RoundTripper {
+	if len(opts.headers) == 0 {
+		return next
+	}
+
+	return transport.NewHeaders(next, http.Header(opts.headers))
+}
+
+// newClient creates sdkClient targeting provided endpoint with transport configured by opts.
+// RateLimit wraps everything but Preflight and Timeout, so 429 responses are typed and logged durations exclude limiter waits.
+// Unavailable wraps Logging, so 502, 503 and 504 responses are still logged before they're turned into errors.
+// Cache wraps RateLimit, so cached responses don't wait for the limiter.
+// Preflight rejects incomplete credentials before they take a slot of the limiter or reach the cache.
+// Timeout is the outermost layer and only sets a default, deadline of the request context takes precedence over it.
+func newClient(endpoint string, base http.RoundTripper, opts options, metrics transport.Metrics) sdkClient {
+	roundTripper := withHeaders(transport.NewRetry(base), opts)
+
+	roundTripper = transport.NewMetering(roundTripper, metrics)
+	if opts.verbose {
+		roundTripper = transport.NewLogging(roundTripper, logRequest)
+	}
+
+	roundTripper = transport.NewUnavailable(roundTripper)
+	roundTripper = transport.NewRateLimit(roundTripper, opts.rateLimit, opts.rateBurst)
+	roundTripper = transport.NewCache(roundTripper, opts.cache)
+	roundTripper = transport.NewPreflight(roundTripper)
+	roundTripper = transport.NewTimeout(roundTripper, opts.timeout)
+
+	return sdkClient{sdk.NewClient().
+		WithHTTPClient(&http.Client{Transport: roundTripper, CheckRedirect: transport.CheckRedirect}).
+		WithBaseURL(endpoint)}
+}
+
+// runCommand executes non-interactive command with cached credentials.
+func runCommand(name string, client apiClient, metrics *transport.Collector, opts options) error {
+	// every request is limited by --timeout on its own, so the whole command isn't
+	ctx := context.Background()
+	if name == command.SelfTestName {
+		return command.SelfTest(ctx, client, clock.Real{}, metrics, os.Stdout)
+	}
+
+	// demo client serves the same data regardless of credentials
+	user := &sdk.Authorization{}
+	if !opts.demo {
+		var err error
+		user, err = register.NewService(nil).WithFolder(opts.configDir).WithProfile(opts.profile).User()
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			return fmt.Errorf("not logged in, register in the interactive mode first")
+		}
+	}
+
+	return command.Run(ctx, client, user, name, opts.json, os.Stdout)
+}
+
 func main() {
-	f, err := tea.LogToFile("debug.log", "debug")
+	name, args := "", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	opts := parseOptions(args)
+	if err := opts.validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if err := ui.SetTheme(opts.theme); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var client apiClient
+	var server string
+	var metrics *transport.Collector
+	var base *http.Transport
+	host := "demo"
+
+	if opts.demo {
+		client = demo.NewClient()
+	} else {
+		server = resolveEndpoint(opts.endpoint, opts.port)
+		metrics = transport.NewCollector()
+		base = newBase(opts)
+		client = newClient(server, base, opts, metrics)
+		host = hostOf(server)
+	}
+
+	// commands print to stdout, so --verbose logs go to stderr instead of debug.log
+	if name != "" {
+		if err := runCommand(name, client, metrics, opts); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	// debug.log and export file are kept in data dir, so running the app doesn't litter the current directory
+	resolved, err := dirs.Resolve(opts.configDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := dirs.Ensure(resolved.Data); err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := tea.LogToFile(filepath.Join(resolved.Data, "debug.log"), "debug")
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer f.Close() //nolint:errcheck
 
-	client := sdk.NewClient()
+	folder := opts.configDir
+	if opts.demo {
+		folder, err = os.MkdirTemp("", "friendly-demo")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer os.RemoveAll(folder) //nolint:errcheck
+	}
+
+	registerService := register.NewService(client).WithFolder(folder).WithProfile(opts.profile)
+	feedService := feed.NewService(client).WithPoll(opts.poll).WithAutoRefresh(opts.refresh)
 	screens := []screen.Model{
 		home.New(),
-		profile.New(profile.NewService(client)),
-		register.New(register.NewService(client)),
+		intro.New(),
+		feed.New(feedService),
+		profile.New(profile.NewService(client).WithFolder(resolved.Data).WithExportPath(opts.export)),
+		edit.New(edit.NewService(client)),
+		friend.New(friend.NewService(client)),
+		network.New(network.NewService(client)),
+		requests.New(requests.NewService(client)),
+		token.New(token.NewService(client)),
+		register.New(registerService),
+		accounts.New(accounts.NewService(registerService)),
+	}
+
+	r := router.NewRouter(screens, host).WithDebug(opts.debug)
+	wrapper := navigation.NewVimWrapper(r)
+
+	p := tea.NewProgram(wrapper, tea.WithMouseCellMotion(), tea.WithReportFocus())
+	if server != "" {
+		go func() {
+			p.Send(router.ConnectionMsg{Err: transport.Ping(context.Background(), server, withHeaders(base, opts))})
+		}()
 	}
 
-	router := router.NewRouter(screens)
-	wrapper := navigation.NewVimWrapper(router)
+	_, runErr := p.Run()
+
+	// credentials are persisted on interrupt too, only a crash of the app skips it
+	if err := registerService.Persist(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	// quitting within undo window of a friend request sends it instead of dropping it
+	if err := feedService.Flush(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	if errors.Is(runErr, tea.ErrInterrupted) || errors.Is(runErr, tea.ErrProgramKilled) {
+		return
+	}
 
-	p := tea.NewProgram(wrapper)
-	if _, err := p.Run(); err != nil {
-		panic("failed to run app router: " + err.Error())
+	if runErr != nil {
+		panic("failed to run app router: " + runErr.Error())
 	}
 }
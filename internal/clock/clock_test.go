@@ -0,0 +1,56 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	short, long := fake.After(time.Second), fake.After(time.Minute)
+	fake.Advance(30 * time.Second)
+
+	select {
+	case at := <-short:
+		if !at.Equal(start.Add(30 * time.Second)) {
+			t.Fatalf("expected to fire at the advanced time, got %s", at)
+		}
+	default:
+		t.Fatal("expected passed duration to fire")
+	}
+
+	select {
+	case <-long:
+		t.Fatal("expected pending duration not to fire")
+	default:
+	}
+
+	if fake.Waiters() != 1 {
+		t.Fatalf("expected 1 waiter left, got %d", fake.Waiters())
+	}
+
+	if got := Since(fake, start); got != 30*time.Second {
+		t.Fatalf("expected 30s to pass, got %s", got)
+	}
+}
+
+func TestTick(t *testing.T) {
+	fake := NewFake(time.Time{})
+	done := make(chan tea.Msg)
+	go func() {
+		done <- Tick(fake, time.Second, func(time.Time) tea.Msg { return "tick" })()
+	}()
+
+	for fake.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	fake.Advance(time.Second)
+
+	if msg := <-done; msg != "tick" {
+		t.Fatalf("expected tick message, got %v", msg)
+	}
+}
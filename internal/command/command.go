@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// Client is a subset of sdk.Client methods used by commands.
+type Client interface {
+	GetFeedQueue(ctx context.Context, auth *sdk.Authorization) (*sdk.FeedQueue, error)
+	GetSelfDetails(ctx context.Context, auth *sdk.Authorization) (*sdk.UserDetails, error)
+	GetNetworkDetails(ctx context.Context, auth *sdk.Authorization) (*sdk.NetworkDetails, error)
+}
+
+// command fetches data and returns it together with rows of its plain table form.
+type command func(ctx context.Context, client Client, user *sdk.Authorization) (any, [][]string, error)
+
+var commands = map[string]command{
+	"feed":    feed,
+	"profile": profile,
+	"network": network,
+}
+
+// Names returns names of all commands, including SelfTestName.
+func Names() []string {
+	names := make([]string, 0, len(commands)+1)
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	names = append(names, SelfTestName)
+
+	slices.Sort(names)
+	return names
+}
+
+// Run executes command with provided name and prints its result to out as JSON or as a plain table.
+func Run(ctx context.Context, client Client, user *sdk.Authorization, name string, asJSON bool, out io.Writer) error {
+	if name == SelfTestName {
+		return fmt.Errorf("command: %s needs a client able to register, run it with SelfTest", name)
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("command: unknown command %q, choose one of: %s", name, strings.Join(Names(), ", "))
+	}
+
+	data, rows, err := cmd(ctx, client, user)
+	if err != nil {
+		return fmt.Errorf("command: %s failed: %w", name, err)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+func interests(value sdk.Interests) string {
+	values := make([]string, 0, len(value.Value()))
+	for _, interest := range value.Value() {
+		values = append(values, interest.Value())
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func row(details sdk.UserDetails) []string {
+	return []string{
+		fmt.Sprint(details.Id.Value()),
+		details.Nickname.Value(),
+		details.Description.Value(),
+		interests(details.Interests),
+		details.SocialLink.Value(),
+	}
+}
+
+var header = []string{"ID", "NICKNAME", "DESCRIPTION", "INTERESTS", "SOCIAL LINK"}
+
+func feed(ctx context.Context, client Client, user *sdk.Authorization) (any, [][]string, error) {
+	queue, err := client.GetFeedQueue(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := [][]string{append(slices.Clone(header), "COMMON FRIENDS", "REQUEST")}
+	for _, entry := range queue.Entries {
+		rows = append(rows, append(row(entry.Details), fmt.Sprint(len(entry.CommonFriends)), fmt.Sprint(entry.IsRequest)))
+	}
+
+	return queue, rows, nil
+}
+
+func profile(ctx context.Context, client Client, user *sdk.Authorization) (any, [][]string, error) {
+	details, err := client.GetSelfDetails(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return details, [][]string{header, row(*details)}, nil
+}
+
+func network(ctx context.Context, client Client, user *sdk.Authorization) (any, [][]string, error) {
+	network, err := client.GetNetworkDetails(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := [][]string{header}
+	for _, friend := range network.Friends {
+		rows = append(rows, row(friend))
+	}
+
+	return network, rows, nil
+}
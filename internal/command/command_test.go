@@ -0,0 +1,91 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/friendly-social/cli/internal/demo"
+)
+
+func TestRun_Table(t *testing.T) {
+	tests := map[string]struct {
+		header    string
+		nicknames []string
+	}{
+		"feed":    {header: "COMMON FRIENDS", nicknames: []string{"carol", "dave", "erin"}},
+		"profile": {header: "SOCIAL LINK", nicknames: []string{"demo"}},
+		"network": {header: "SOCIAL LINK", nicknames: []string{"alice", "bob"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Run(context.Background(), demo.NewClient(), nil, name, false, &out); err != nil {
+				t.Fatal(err)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+			if len(lines) != len(test.nicknames)+1 {
+				t.Fatalf("expected header and %d rows, got:\n%s", len(test.nicknames), out.String())
+			}
+
+			if !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[0], test.header) {
+				t.Fatalf("expected header with %s, got %q", test.header, lines[0])
+			}
+
+			for i, nickname := range test.nicknames {
+				if fields := strings.Fields(lines[i+1]); len(fields) < 2 || fields[1] != nickname {
+					t.Fatalf("expected row %d to be about %s, got %q", i+1, nickname, lines[i+1])
+				}
+			}
+		})
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	client := demo.NewClient()
+	queue, _ := client.GetFeedQueue(context.Background(), nil)
+	details, _ := client.GetSelfDetails(context.Background(), nil)
+	network, _ := client.GetNetworkDetails(context.Background(), nil)
+
+	tests := map[string]any{
+		"feed":    queue,
+		"profile": details,
+		"network": network,
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Run(context.Background(), client, nil, name, true, &out); err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := json.MarshalIndent(data, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if out.String() != string(expected)+"\n" {
+				t.Fatalf("expected:\n%s\ngot:\n%s", expected, out.String())
+			}
+		})
+	}
+}
+
+func TestRun_Unknown(t *testing.T) {
+	err := Run(context.Background(), demo.NewClient(), nil, "friends", false, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected error of unknown command")
+	}
+
+	// selftest isn't run by Run, but it's a valid command all the same
+	for _, name := range Names() {
+		if !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected error to list %s, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package command implements non-interactive subcommands which print API data to stdout for scripting.
+package command
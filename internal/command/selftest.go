@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/transport"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// SelfTestName is the name of command running SelfTest.
+const SelfTestName = "selftest"
+
+// SelfTestClient is a subset of sdk.Client methods exercised by SelfTest.
+type SelfTestClient interface {
+	Client
+	Register(ctx context.Context, nickname sdk.Nickname, description sdk.UserDescription, interests sdk.Interests, avatar *sdk.FileDescriptor, link sdk.SocialLink) (*sdk.Authorization, error)
+	GenerateFriendToken(ctx context.Context, auth *sdk.Authorization) (sdk.FriendToken, error)
+	UploadFile(ctx context.Context, filename string, reader io.Reader) (*sdk.FileDescriptor, error)
+}
+
+// step is a single check of SelfTest, which may use user registered by the first step.
+type step struct {
+	name string
+	run  func(ctx context.Context, user **sdk.Authorization) error
+}
+
+// register creates throwaway account used by the rest of steps.
+func register(client SelfTestClient) func(ctx context.Context, user **sdk.Authorization) error {
+	return func(ctx context.Context, user **sdk.Authorization) error {
+		nickname, _ := sdk.NewNickname("selftest")
+		description, _ := sdk.NewUserDescription("throwaway account created by friendly selftest")
+		interest, _ := sdk.NewInterest("selftest")
+		interests, _ := sdk.NewInterests(interest)
+		link, _ := sdk.NewSocialLink("https://example.com/selftest")
+
+		result, err := client.Register(ctx, nickname, description, interests, nil, link)
+		*user = result
+		return err
+	}
+}
+
+// SelfTest exercises every API endpoint used by the CLI with a throwaway account
+// and prints pass or fail with timing for each of them measured by c, followed by numbers of metrics if it isn't nil.
+// Returns error if any step failed.
+func SelfTest(ctx context.Context, client SelfTestClient, c clock.Clock, metrics *transport.Collector, out io.Writer) error {
+	steps := []step{
+		{"register", register(client)},
+		{"generate friend token", func(ctx context.Context, user **sdk.Authorization) error {
+			_, err := client.GenerateFriendToken(ctx, *user)
+			return err
+		}},
+		{"profile", func(ctx context.Context, user **sdk.Authorization) error {
+			_, err := client.GetSelfDetails(ctx, *user)
+			return err
+		}},
+		{"network", func(ctx context.Context, user **sdk.Authorization) error {
+			_, err := client.GetNetworkDetails(ctx, *user)
+			return err
+		}},
+		{"feed", func(ctx context.Context, user **sdk.Authorization) error {
+			_, err := client.GetFeedQueue(ctx, *user)
+			return err
+		}},
+		{"upload file", func(ctx context.Context, _ **sdk.Authorization) error {
+			_, err := client.UploadFile(ctx, "selftest.txt", strings.NewReader("friendly selftest"))
+			return err
+		}},
+	}
+
+	var user *sdk.Authorization
+	failed := 0
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	for _, step := range steps {
+		if user == nil && step.name != "register" {
+			fmt.Fprintf(writer, "SKIP\t%s\t\tno account\n", step.name)
+			failed++
+			continue
+		}
+
+		start := c.Now()
+		err := step.run(ctx, &user)
+		took := clock.Since(c, start).Round(time.Millisecond)
+		if err != nil {
+			fmt.Fprintf(writer, "FAIL\t%s\t%s\t%s\n", step.name, took, err)
+			failed++
+			continue
+		}
+
+		fmt.Fprintf(writer, "PASS\t%s\t%s\t\n", step.name, took)
+	}
+
+	// golang-sdk has no DeleteAccount, so the throwaway account stays on the server
+	fmt.Fprintln(writer, "NOTE\tcleanup\t\tthrowaway account can't be deleted, golang-sdk has no DeleteAccount")
+	if metrics != nil {
+		fmt.Fprintln(writer, "\nENDPOINT\tREQUESTS\tERRORS\tMEAN\tMAX")
+		snapshot := metrics.Snapshot()
+		for _, path := range slices.Sorted(maps.Keys(snapshot)) {
+			endpoint := snapshot[path]
+			fmt.Fprintf(writer, "%s\t%d\t%d\t%s\t%s\n", path, endpoint.Requests, endpoint.Errors,
+				endpoint.Mean().Round(time.Millisecond), endpoint.Max.Round(time.Millisecond))
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("command: %d of %d selftest steps failed", failed, len(steps))
+	}
+
+	return nil
+}
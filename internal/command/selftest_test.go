@@ -0,0 +1,54 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/demo"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// unregistered fails to register, so the rest of steps have no account.
+type unregistered struct {
+	*demo.Client
+}
+
+func (unregistered) Register(context.Context, sdk.Nickname, sdk.UserDescription, sdk.Interests, *sdk.FileDescriptor, sdk.SocialLink) (*sdk.Authorization, error) {
+	return nil, errors.New("registration is closed")
+}
+
+func TestSelfTest(t *testing.T) {
+	var out bytes.Buffer
+	err := SelfTest(context.Background(), demo.NewClient(), clock.NewFake(time.Unix(0, 0)), nil, &out)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+
+	for _, step := range []string{"register", "generate friend token", "profile", "network", "feed", "upload file"} {
+		if !strings.Contains(out.String(), "PASS  "+step+"  ") {
+			t.Fatalf("expected %s to pass, got:\n%s", step, out.String())
+		}
+	}
+
+	// fake clock stands still, so every step takes no time
+	if strings.Count(out.String(), " 0s ") != 6 {
+		t.Fatalf("expected timing of fake clock, got:\n%s", out.String())
+	}
+}
+
+func TestSelfTest_Unregistered(t *testing.T) {
+	var out bytes.Buffer
+	err := SelfTest(context.Background(), unregistered{demo.NewClient()}, clock.NewFake(time.Unix(0, 0)), nil, &out)
+	if err == nil || !strings.Contains(err.Error(), "6 of 6") {
+		t.Fatalf("expected every step to fail, got %v", err)
+	}
+
+	if !strings.Contains(out.String(), "registration is closed") || strings.Count(out.String(), "SKIP") != 5 {
+		t.Fatalf("expected failed registration to skip the rest of steps, got:\n%s", out.String())
+	}
+}
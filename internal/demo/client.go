@@ -0,0 +1,229 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/friendly-social/cli/internal/screen/edit"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// Client mimics sdk.Client without network access. Every Authorization is treated as the single demo user.
+type Client struct {
+	mu      sync.Mutex
+	self    sdk.UserDetails
+	friends []sdk.UserDetails
+	feed    []sdk.FeedEntry
+}
+
+// hash builds valid 256 characters long string unique for provided ID.
+func hash(id int64) string {
+	return fmt.Sprintf("%0256d", id)
+}
+
+// user builds UserDetails from plain values, which are known to be valid.
+func user(id int64, nickname, description, link string, interests ...string) sdk.UserDetails {
+	accessHash, _ := sdk.NewUserAccessHash(hash(id))
+	nick, _ := sdk.NewNickname(nickname)
+	desc, _ := sdk.NewUserDescription(description)
+	social, _ := sdk.NewSocialLink(link)
+
+	values := make([]sdk.Interest, 0, len(interests))
+	for _, interest := range interests {
+		value, _ := sdk.NewInterest(interest)
+		values = append(values, value)
+	}
+
+	interestsValue, _ := sdk.NewInterests(values...)
+	return sdk.UserDetails{
+		Id:          sdk.NewUserId(id),
+		AccessHash:  accessHash,
+		Nickname:    nick,
+		Description: desc,
+		Interests:   interestsValue,
+		SocialLink:  social,
+	}
+}
+
+// NewClient creates new Client filled with canned data.
+func NewClient() *Client {
+	alice := user(2, "alice", "likes long walks and short programs", "https://example.com/alice", "hiking", "go")
+	bob := user(3, "bob", "terminal enthusiast", "https://example.com/bob", "vim", "music")
+	carol := user(4, "carol", "drawing every day", "https://example.com/carol", "art", "coffee")
+	dave := user(5, "dave", "looking for a chess partner", "https://example.com/dave", "chess", "go")
+	erin := user(6, "erin", "photographer and traveller", "https://example.com/erin", "photo", "hiking")
+
+	return &Client{
+		self:    user(1, "demo", "this account exists only in demo mode", "https://example.com/demo", "friendly"),
+		friends: []sdk.UserDetails{alice, bob},
+		feed: []sdk.FeedEntry{
+			{Details: carol, CommonFriends: []sdk.UserDetails{alice}},
+			{Details: dave, CommonFriends: []sdk.UserDetails{alice, bob}, IsRequest: true},
+			{Details: erin, IsExtendedNetwork: true},
+		},
+	}
+}
+
+// Register returns demo user's Authorization and replaces its details with provided ones.
+func (c *Client) Register(_ context.Context, nickname sdk.Nickname, description sdk.UserDescription, interests sdk.Interests, avatar *sdk.FileDescriptor, link sdk.SocialLink) (*sdk.Authorization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.self.Nickname = nickname
+	c.self.Description = description
+	c.self.Interests = interests
+	c.self.Avatar = avatar
+	c.self.SocialLink = link
+
+	token, _ := sdk.NewToken(hash(c.self.Id.Value()))
+	return &sdk.Authorization{
+		Id:         c.self.Id,
+		AccessHash: c.self.AccessHash,
+		Token:      token,
+	}, nil
+}
+
+// EditProfile applies changes to demo user's details.
+func (c *Client) EditProfile(_ context.Context, _ *sdk.Authorization, changes edit.Changes) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if changes.Nickname != nil {
+		c.self.Nickname = *changes.Nickname
+	}
+
+	if changes.Description != nil {
+		c.self.Description = *changes.Description
+	}
+
+	if changes.Interests != nil {
+		c.self.Interests = *changes.Interests
+	}
+
+	return nil
+}
+
+// GetSelfDetails returns demo user's details.
+func (c *Client) GetSelfDetails(context.Context, *sdk.Authorization) (*sdk.UserDetails, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	self := c.self
+	return &self, nil
+}
+
+// GetUserDetails returns details of demo user, their friend or feed entry with provided ID.
+func (c *Client) GetUserDetails(_ context.Context, _ *sdk.Authorization, userId sdk.UserId, _ sdk.UserAccessHash) (*sdk.UserDetails, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	users := append([]sdk.UserDetails{c.self}, c.friends...)
+	for _, entry := range c.feed {
+		users = append(users, entry.Details)
+	}
+
+	index := slices.IndexFunc(users, func(user sdk.UserDetails) bool {
+		return user.Id == userId
+	})
+	if index < 0 {
+		return nil, fmt.Errorf("demo: user %d doesn't exist", userId.Value())
+	}
+
+	return &users[index], nil
+}
+
+// GetNetworkDetails returns demo user's friends.
+func (c *Client) GetNetworkDetails(context.Context, *sdk.Authorization) (*sdk.NetworkDetails, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &sdk.NetworkDetails{Friends: slices.Clone(c.friends)}, nil
+}
+
+// GetFeedQueue returns feed entries to which demo user hasn't sent requests yet.
+func (c *Client) GetFeedQueue(context.Context, *sdk.Authorization) (*sdk.FeedQueue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &sdk.FeedQueue{Entries: slices.Clone(c.feed)}, nil
+}
+
+// AddFriend moves user from the feed to demo user's friends. Any valid token is accepted.
+func (c *Client) AddFriend(_ context.Context, _ *sdk.Authorization, _ sdk.FriendToken, userId sdk.UserId) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := slices.IndexFunc(c.feed, func(entry sdk.FeedEntry) bool {
+		return entry.Details.Id == userId
+	})
+	if index < 0 {
+		return fmt.Errorf("demo: user %d is not in the feed", userId.Value())
+	}
+
+	c.friends = append(c.friends, c.feed[index].Details)
+	c.feed = slices.Delete(c.feed, index, index+1)
+	return nil
+}
+
+// GenerateFriendToken returns the same valid token for every call.
+func (c *Client) GenerateFriendToken(context.Context, *sdk.Authorization) (sdk.FriendToken, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return sdk.NewFriendToken(hash(c.self.Id.Value()))
+}
+
+// UploadFile reads the whole file and returns descriptor of a file which is never stored.
+func (c *Client) UploadFile(_ context.Context, _ string, reader io.Reader) (*sdk.FileDescriptor, error) {
+	_, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		return nil, fmt.Errorf("demo: failed to read file: %w", err)
+	}
+
+	accessHash, _ := sdk.NewFileAccessHash(hash(1))
+	return &sdk.FileDescriptor{Id: sdk.NewFileId(1), AccessHash: accessHash}, nil
+}
+
+// DeclineFriendRequest removes user from the feed without making them a friend.
+func (c *Client) DeclineFriendRequest(_ context.Context, _ *sdk.Authorization, userId sdk.UserId, _ sdk.UserAccessHash) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := slices.IndexFunc(c.feed, func(entry sdk.FeedEntry) bool {
+		return entry.Details.Id == userId && entry.IsRequest
+	})
+	if index < 0 {
+		return fmt.Errorf("demo: user %d hasn't sent a friend request", userId.Value())
+	}
+
+	c.feed = slices.Delete(c.feed, index, index+1)
+	return nil
+}
+
+// GetFileURL returns URL of a file, which never exists in demo mode.
+func (c *Client) GetFileURL(fd *sdk.FileDescriptor) (string, error) {
+	return fmt.Sprintf("https://example.com/files/%d", fd.Id.Value()), nil
+}
+
+// SendFriendRequest removes user from the feed, making them a friend if they requested it too.
+func (c *Client) SendFriendRequest(_ context.Context, _ *sdk.Authorization, userId sdk.UserId, _ sdk.UserAccessHash) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := slices.IndexFunc(c.feed, func(entry sdk.FeedEntry) bool {
+		return entry.Details.Id == userId
+	})
+	if index < 0 {
+		return fmt.Errorf("demo: user %d is not in the feed", userId.Value())
+	}
+
+	if c.feed[index].IsRequest {
+		c.friends = append(c.friends, c.feed[index].Details)
+	}
+
+	c.feed = slices.Delete(c.feed, index, index+1)
+	return nil
+}
@@ -0,0 +1,2 @@
+// Package demo provides offline stand-in for sdk.Client which serves deterministic canned data.
+package demo
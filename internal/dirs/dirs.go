@@ -0,0 +1,61 @@
+// Package dirs resolves directories the application keeps its files in.
+package dirs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const name = "friendly"
+
+// Dirs holds directories of configuration and data, like saved credentials.
+type Dirs struct {
+	Config string
+	Data   string
+}
+
+// Resolve finds directories following XDG Base Directory specification.
+// Non-empty override replaces both of them, so everything is kept in a single directory.
+func Resolve(override string) (Dirs, error) {
+	if override != "" {
+		return Dirs{Config: override, Data: override}, nil
+	}
+
+	config, err := xdg("XDG_CONFIG_HOME", ".config")
+	if err != nil {
+		return Dirs{}, err
+	}
+
+	data, err := xdg("XDG_DATA_HOME", filepath.Join(".local", "share"))
+	if err != nil {
+		return Dirs{}, err
+	}
+
+	return Dirs{Config: config, Data: data}, nil
+}
+
+// xdg returns application directory inside the one from env, or inside fallback relative to home.
+// Relative paths in env are invalid according to the specification and are ignored.
+func xdg(env, fallback string) (string, error) {
+	if base := os.Getenv(env); filepath.IsAbs(base) {
+		return filepath.Join(base, name), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("dirs: failed to get home dir: %w", err)
+	}
+
+	return filepath.Join(home, fallback, name), nil
+}
+
+// Ensure creates dir if it doesn't exist. It's accessible only by the user, since credentials live there.
+func Ensure(dir string) error {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return fmt.Errorf("dirs: failed to create %s: %w", dir, err)
+	}
+
+	return nil
+}
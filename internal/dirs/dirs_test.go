@@ -0,0 +1,76 @@
+package dirs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	home := t.TempDir()
+	tests := map[string]struct {
+		override string
+		config   string
+		data     string
+		expected Dirs
+	}{
+		"override": {
+			override: "/custom",
+			config:   "/xdg/config",
+			data:     "/xdg/data",
+			expected: Dirs{Config: "/custom", Data: "/custom"},
+		},
+		"xdg": {
+			config:   "/xdg/config",
+			data:     "/xdg/data",
+			expected: Dirs{Config: "/xdg/config/friendly", Data: "/xdg/data/friendly"},
+		},
+		"fallback": {
+			expected: Dirs{
+				Config: filepath.Join(home, ".config", "friendly"),
+				Data:   filepath.Join(home, ".local", "share", "friendly"),
+			},
+		},
+		"relative xdg": {
+			config: "relative/config",
+			data:   "relative/data",
+			expected: Dirs{
+				Config: filepath.Join(home, ".config", "friendly"),
+				Data:   filepath.Join(home, ".local", "share", "friendly"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			t.Setenv("XDG_CONFIG_HOME", test.config)
+			t.Setenv("XDG_DATA_HOME", test.data)
+
+			dirs, err := Resolve(test.override)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dirs != test.expected {
+				t.Fatalf("expected %+v, got %+v", test.expected, dirs)
+			}
+		})
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "friendly")
+	if err := Ensure(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0700 {
+		t.Fatalf("expected permissions 0700, got %o", perm)
+	}
+}
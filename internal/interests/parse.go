@@ -0,0 +1,52 @@
+// Package interests parses interests entered or pasted by user.
+package interests
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// delimiters separate interests in entered text.
+const delimiters = ",;\n\r"
+
+// Parse splits text on commas, semicolons and newlines, trimming every part and skipping empty ones.
+// Parts repeating earlier ones, ignoring case, are dropped. Every invalid part is reported by its own error,
+// so valid interests are returned even if some of them were rejected.
+func Parse(text string) ([]sdk.Interest, []error) {
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune(delimiters, r)
+	})
+
+	seen := make(map[string]bool, len(parts))
+	result := make([]sdk.Interest, 0, len(parts))
+	errs := make([]error, 0)
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" || seen[strings.ToLower(part)] {
+			continue
+		}
+
+		seen[strings.ToLower(part)] = true
+		interest, err := sdk.NewInterest(part)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("interests: %q is rejected: %w", part, err))
+			continue
+		}
+
+		result = append(result, interest)
+	}
+
+	return result, errs
+}
+
+// Values returns text of every interest.
+func Values(interests []sdk.Interest) []string {
+	values := make([]string, 0, len(interests))
+	for _, interest := range interests {
+		values = append(values, interest.Value())
+	}
+
+	return values
+}
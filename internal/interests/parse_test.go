@@ -0,0 +1,34 @@
+package interests
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		expected []string
+		errors   int
+	}{
+		"commas":           {text: "chess, go", expected: []string{"chess", "go"}},
+		"mixed delimiters": {text: "chess;go\nart\r\ncoffee", expected: []string{"chess", "go", "art", "coffee"}},
+		"empty parts":      {text: ",chess,, ;go,", expected: []string{"chess", "go"}},
+		"duplicates":       {text: "Chess, chess, CHESS", expected: []string{"Chess"}},
+		"invalid part":     {text: "chess, " + strings.Repeat("x", 1000) + ", go", expected: []string{"chess", "go"}, errors: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			parsed, errs := Parse(test.text)
+			if values := Values(parsed); !slices.Equal(values, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, values)
+			}
+
+			if len(errs) != test.errors {
+				t.Fatalf("expected %d errors, got %v", test.errors, errs)
+			}
+		})
+	}
+}
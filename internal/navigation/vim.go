@@ -2,6 +2,7 @@ package navigation
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,9 +18,13 @@ const (
 )
 
 // VimWrapper translates raw tea.KeyMsgs to UI messages using Vim motions driven logic.
+// Esc is two-staged: in insert mode it only returns to normal mode and never reaches the model,
+// so screens are free to treat esc in normal mode as going back.
+// ? in normal mode shows cheat sheet of all shortcuts, which any key closes.
 type VimWrapper struct {
 	mode  VimMode
 	model tea.Model
+	sheet bool
 
 	width  int
 	height int
@@ -47,10 +52,22 @@ func (w VimWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		w.model, cmd = w.model.Update(msg)
 		return w, cmd
+	case tea.MouseMsg:
+		if w.mode == VimModeInsert || w.sheet {
+			return w, nil
+		}
 	case tea.KeyMsg:
+		if w.sheet {
+			w.sheet = false
+			return w, nil
+		}
+
 		switch w.mode {
 		case VimModeNormal:
 			switch msg.String() {
+			case "?":
+				w.sheet = true
+				return w, nil
 			case "i":
 				w.mode = VimModeInsert
 				return w, func() tea.Msg {
@@ -84,6 +101,10 @@ func (w VimWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return w, func() tea.Msg {
 					return ui.UnfocusMsg{}
 				}
+			case "tab":
+				return w, ui.Next()
+			case "shift+tab":
+				return w, ui.Previous()
 			}
 		}
 	}
@@ -93,21 +114,88 @@ func (w VimWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return w, cmd
 }
 
+// help lists keys available in insert mode, starting with the ones of the focused component.
+func (w VimWrapper) help() string {
+	keys := []string{"tab/shift+tab switch field", "esc normal mode"}
+	if helper, ok := w.model.(ui.Helper); ok && helper.Help() != "" {
+		keys = append([]string{helper.Help()}, keys...)
+	}
+
+	return strings.Join(keys, " • ")
+}
+
 func (w VimWrapper) footer() string {
+	status := fmt.Sprintf("--- %s ---", w.mode)
+	if w.mode == VimModeInsert {
+		status += " " + w.help()
+	} else {
+		status += " ? shortcuts"
+	}
+
 	return lipgloss.NewStyle().
 		Align(lipgloss.Left).
 		Width(w.width).
 		Border(lipgloss.InnerHalfBlockBorder(), true, false, false, false).
-		Render(fmt.Sprintf("--- %s ---", w.mode))
+		// border and a single line, so help cut by narrow terminal doesn't change height reserved in Update
+		MaxHeight(2).
+		Render(status)
+}
+
+// cheatSheet lists motions followed by shortcuts known to the model, flowing sections into columns
+// so they fit into height.
+func (w VimWrapper) cheatSheet(height int) string {
+	sections := []ui.Section{{Title: "navigation", Bindings: []ui.Binding{
+		{Key: "h/j/k/l", Action: "move, arrows work too"},
+		{Key: "enter", Action: "press button or go to the next field"},
+		{Key: "i", Action: "insert mode, to type into fields"},
+		{Key: "tab", Action: "switch field in insert mode, shift+tab goes back"},
+		{Key: "esc", Action: "leave insert mode"},
+		{Key: "?", Action: "show this cheat sheet"},
+	}}}
+	if sheet, ok := w.model.(ui.Sheet); ok {
+		sections = append(sections, sheet.Sheet()...)
+	}
+
+	title := "keyboard shortcuts (press any key to close)"
+	height -= lipgloss.Height(title) + 1
+
+	var columns, column []string
+	for _, section := range sections {
+		width := 0
+		for _, binding := range section.Bindings {
+			width = max(width, lipgloss.Width(binding.Key))
+		}
+
+		lines := []string{lipgloss.NewStyle().Bold(true).Render(section.Title)}
+		for _, binding := range section.Bindings {
+			lines = append(lines, fmt.Sprintf("  %-*s  %s", width, binding.Key, binding.Action))
+		}
+
+		block := strings.Join(lines, "\n")
+		if len(column) != 0 && lipgloss.Height(strings.Join(append(column, block), "\n\n")) > height {
+			columns = append(columns, lipgloss.NewStyle().PaddingRight(4).Render(strings.Join(column, "\n\n")))
+			column = nil
+		}
+
+		column = append(column, block)
+	}
+
+	columns = append(columns, strings.Join(column, "\n\n"))
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", lipgloss.JoinHorizontal(lipgloss.Top, columns...))
 }
 
 func (w VimWrapper) View() string {
 	footer := w.footer()
 
+	view := w.model.View()
+	if w.sheet {
+		view = w.cheatSheet(w.height - lipgloss.Height(footer))
+	}
+
 	content := lipgloss.NewStyle().
 		Width(w.width).
 		Height(w.height - lipgloss.Height(footer)).
-		Render(w.model.View())
+		Render(view)
 
 	return lipgloss.JoinVertical(lipgloss.Top, content, footer)
 }
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/secret"
+)
+
+// debugLines returns data behind the current screen as indented JSON split by lines, with credentials masked.
+func (r Router) debugLines() []string {
+	debugger, ok := r.screens[r.current].(screen.Debugger)
+	if !ok {
+		return []string{fmt.Sprintf("%s screen has no data to show", r.current)}
+	}
+
+	bytes, err := json.MarshalIndent(secret.Redact(debugger.Debug()), "", "  ")
+	if err != nil {
+		return []string{fmt.Sprintf("failed to marshal data: %s", err)}
+	}
+
+	return strings.Split(string(bytes), "\n")
+}
+
+// debugView renders visible part of raw data pane, which is height lines tall.
+func (r Router) debugView(height int) string {
+	lines := r.debugLines()
+	height = max(height, 1)
+	offset := min(r.debugOffset, max(len(lines)-height, 0))
+
+	return strings.Join(lines[offset:min(offset+height, len(lines))], "\n")
+}
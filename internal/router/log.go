@@ -0,0 +1,57 @@
+package router
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/ui"
+)
+
+const logCapacity = 20
+
+type logEntry struct {
+	at  time.Time
+	msg LogMsg
+}
+
+// journal is a ring buffer which keeps only the latest logCapacity entries.
+type journal struct {
+	entries [logCapacity]logEntry
+	start   int
+	size    int
+	clock   clock.Clock
+}
+
+func (j *journal) add(msg LogMsg) {
+	j.entries[(j.start+j.size)%logCapacity] = logEntry{at: j.clock.Now(), msg: msg}
+	if j.size < logCapacity {
+		j.size++
+		return
+	}
+
+	j.start = (j.start + 1) % logCapacity
+}
+
+func (j *journal) View() string {
+	if j.size == 0 {
+		return "log is empty"
+	}
+
+	lines := make([]string, j.size)
+	for i := range j.size {
+		entry := j.entries[(j.start+i)%logCapacity]
+		style := lipgloss.NewStyle()
+		switch entry.msg.Severity {
+		case SeveritySuccess:
+			style = ui.SuccessStyle()
+		case SeverityError:
+			style = ui.ErrorStyle()
+		}
+
+		lines[i] = style.Render(fmt.Sprintf("%s %s", entry.at.Format(time.TimeOnly), entry.msg.Text))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/friendly-social/cli/internal/screen"
 )
@@ -15,3 +17,45 @@ type TargetMsg struct {
 	Type  screen.Type
 	Inner tea.Msg
 }
+
+// StatusMsg tells router to update logged in user's data shown in status bar.
+// FriendsFailed tells that friends couldn't be loaded, so Friends isn't known.
+type StatusMsg struct {
+	Nickname      string
+	Friends       int
+	FriendsFailed bool
+}
+
+// RequestsMsg tells router how many friend requests are pending, shown in status bar.
+// Increased highlights the count until the next RequestsMsg.
+type RequestsMsg struct {
+	Count     int
+	Increased bool
+}
+
+// PollingMsg tells router how many polls of friend requests failed in a row and when the next one happens,
+// shown in status bar. Zero Failures means polling works.
+type PollingMsg struct {
+	Failures int
+	Retry    time.Duration
+}
+
+// ConnectionMsg tells router whether the server is reachable, shown in status bar.
+type ConnectionMsg struct {
+	Err error
+}
+
+// Severity represents importance of the message recorded in log.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeveritySuccess
+	SeverityError
+)
+
+// LogMsg tells router to record message in the log. Errors from screen.ErrorMsg are recorded automatically.
+type LogMsg struct {
+	Severity Severity
+	Text     string
+}
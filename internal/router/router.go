@@ -1,22 +1,46 @@
 package router
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/clock"
 	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/ui"
 )
 
 // Router orchestrates multiple screens.
 type Router struct {
 	current screen.Type
 	screens map[screen.Type]screen.Model
+	// history holds screens visited since home, BackMsg returns to the last of them.
+	history []screen.Type
+
+	host        string
+	unreachable bool
+	status      *StatusMsg
+	requests    *RequestsMsg
+	polling     PollingMsg
+
+	log     *journal
+	showLog bool
+
+	debug       bool
+	showDebug   bool
+	debugOffset int
 
 	width  int
 	height int
 }
 
-// NewRouter creates new Router based on provided screens.
-func NewRouter(models []screen.Model) Router {
+// NewRouter creates new Router based on provided screens and host of the server client is connected to.
+func NewRouter(models []screen.Model, host string) Router {
 	screens := make(map[screen.Type]screen.Model)
 	for _, m := range models {
 		screens[m.ID()] = m
@@ -25,9 +49,23 @@ func NewRouter(models []screen.Model) Router {
 	return Router{
 		current: models[0].ID(),
 		screens: screens,
+		host:    host,
+		log:     &journal{clock: clock.Real{}},
 	}
 }
 
+// WithClock sets clock timestamping entries of the log.
+func (r Router) WithClock(c clock.Clock) Router {
+	r.log.clock = c
+	return r
+}
+
+// WithDebug enables raw data pane toggled by ctrl+r.
+func (r Router) WithDebug(enabled bool) Router {
+	r.debug = enabled
+	return r
+}
+
 func (r Router) Init() tea.Cmd {
 	cmds := make([]tea.Cmd, 0, len(r.screens))
 	for _, s := range r.screens {
@@ -55,18 +93,130 @@ func (r Router) broadcast(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return r, tea.Batch(cmds...)
 }
 
+// updateLog handles input while log is shown, so it never reaches the hidden screen.
+func (r Router) updateLog(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "esc" || msg.String() == "ctrl+l" {
+			r.showLog = false
+		}
+
+		return r, nil, true
+	case tea.MouseMsg, ui.MoveMsg, ui.InteractMsg, ui.FocusMsg:
+		return r, nil, true
+	}
+
+	return r, nil, false
+}
+
+// updateDebug handles input while raw data pane is shown, scrolling it instead of the hidden screen.
+func (r Router) updateDebug(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "esc" || msg.String() == "ctrl+r" {
+			r.showDebug = false
+		}
+
+		return r, nil, true
+	case ui.MoveMsg:
+		switch msg.Direction {
+		case ui.DirectionDown:
+			r.debugOffset = min(r.debugOffset+1, max(len(r.debugLines())-1, 0))
+		case ui.DirectionUp:
+			r.debugOffset = max(r.debugOffset-1, 0)
+		}
+
+		return r, nil, true
+	case tea.MouseMsg, ui.InteractMsg, ui.FocusMsg:
+		return r, nil, true
+	}
+
+	return r, nil, false
+}
+
+// change switches to screen with provided type, remembering the current one in history.
+// Returning to a screen which is already in history drops everything visited after it, and home clears history.
+func (r *Router) change(next screen.Type) {
+	switch index := slices.Index(r.history, next); {
+	case next == screen.TypeHome:
+		r.history = nil
+	case index >= 0:
+		r.history = r.history[:index]
+	case next != r.current:
+		r.history = append(r.history, r.current)
+	}
+
+	r.current = next
+}
+
 func (r Router) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if r.showDebug {
+		if model, cmd, handled := r.updateDebug(msg); handled {
+			return model, cmd
+		}
+	}
+
+	if r.showLog {
+		if model, cmd, handled := r.updateLog(msg); handled {
+			return model, cmd
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		r.width = msg.Width
 		r.height = msg.Height
 
-		msg.Height -= lipgloss.Height(r.header())
+		msg.Height -= lipgloss.Height(r.header()) + lipgloss.Height(r.footer())
 		return r.broadcast(msg)
+	case tea.MouseMsg:
+		return r.target(r.current, ui.ShiftMouse(msg, r.header()))
+	case StatusMsg:
+		r.status = &msg
+		return r, nil
+	case RequestsMsg:
+		r.requests = &msg
+		return r, nil
+	case PollingMsg:
+		r.polling = msg
+		return r, nil
+	case tea.FocusMsg, tea.BlurMsg:
+		return r.broadcast(msg)
+	case ConnectionMsg:
+		r.unreachable = msg.Err != nil
+		return r, nil
 	case screen.ChangeMsg:
-		r.current = msg.NewType
+		r.change(msg.NewType)
 		return r, nil
+	case screen.BackMsg:
+		r.current = screen.TypeHome
+		if len(r.history) != 0 {
+			r.current = r.history[len(r.history)-1]
+			r.history = r.history[:len(r.history)-1]
+		}
+
+		return r, nil
+	case LogMsg:
+		r.log.add(msg)
+		return r, nil
+	case screen.ErrorMsg:
+		r.log.add(LogMsg{Severity: SeverityError, Text: msg.Value.Error()})
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+l" {
+			r.showLog = !r.showLog
+			return r, nil
+		}
+
+		if r.debug && msg.String() == "ctrl+r" {
+			r.showDebug = true
+			r.debugOffset = 0
+			return r, nil
+		}
 	case TargetMsg:
+		if inner, ok := msg.Inner.(screen.ErrorMsg); ok {
+			r.log.add(LogMsg{Severity: SeverityError, Text: inner.Value.Error()})
+		}
+
 		return r.target(msg.Type, msg.Inner)
 	case BroadcastMsg:
 		return r.broadcast(msg.Inner)
@@ -75,21 +225,118 @@ func (r Router) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return r.target(r.current, msg)
 }
 
+// Help returns keys available on the current screen while editing, if it describes them.
+func (r Router) Help() string {
+	if helper, ok := r.screens[r.current].(ui.Helper); ok {
+		return helper.Help()
+	}
+
+	return ""
+}
+
+// Sheet returns keys handled by Router followed by shortcuts of the current screen and then of the other ones.
+func (r Router) Sheet() []ui.Section {
+	global := []ui.Binding{{Key: "esc", Action: "go back"}, {Key: "ctrl+l", Action: "toggle log"}}
+	if r.debug {
+		global = append(global, ui.Binding{Key: "ctrl+r", Action: "show raw data of the screen"})
+	}
+
+	types := slices.Sorted(maps.Keys(r.screens))
+	types = slices.DeleteFunc(types, func(t screen.Type) bool { return t == r.current })
+	sections := []ui.Section{{Title: "everywhere", Bindings: global}}
+	for _, t := range append([]screen.Type{r.current}, types...) {
+		if binder, ok := r.screens[t].(ui.Binder); ok {
+			sections = append(sections, ui.Section{Title: string(t) + " screen", Bindings: binder.Bindings()})
+		}
+	}
+
+	return sections
+}
+
+// breadcrumbs returns path to the current screen, which BackMsg walks back.
+func (r Router) breadcrumbs() string {
+	path := make([]string, 0, len(r.history)+1)
+	for _, previous := range r.history {
+		path = append(path, string(previous))
+	}
+
+	return strings.Join(append(path, string(r.current)), " › ")
+}
+
 func (r Router) header() string {
+	title := "Friendly CLI · " + r.breadcrumbs()
+	// shortened to a single line, since height of the header is subtracted from screens only on resize
+	if r.width > 0 {
+		title = ui.Shorten(title, r.width)
+	}
+
 	return lipgloss.NewStyle().
 		Align(lipgloss.Center).
 		Width(r.width).
 		Border(lipgloss.InnerHalfBlockBorder(), false, false, true, false).
-		Render("Friendly CLI")
+		Render(title)
+}
+
+func (r Router) footer() string {
+	host := r.host
+	if r.unreachable {
+		host += " (unreachable)"
+	}
+
+	user := "not logged in"
+	if r.status != nil {
+		friends := strconv.Itoa(r.status.Friends)
+		if r.status.FriendsFailed {
+			friends = "—"
+		}
+
+		user = fmt.Sprintf("%s (%s friends)", r.status.Nickname, friends)
+	}
+
+	footer := fmt.Sprintf("%s | %s", host, user)
+	if r.requests != nil {
+		requests := fmt.Sprintf("%d requests", r.requests.Count)
+		if r.requests.Increased {
+			requests = ui.SuccessStyle().Render(requests + " (new!)")
+		}
+
+		footer += " | " + requests
+	}
+
+	if r.polling.Failures != 0 {
+		footer += " | " + ui.ErrorStyle().Render(fmt.Sprintf("polling failed %d times, retrying in %s",
+			r.polling.Failures, r.polling.Retry.Round(time.Second)))
+	}
+
+	return lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Width(r.width).
+		Border(lipgloss.InnerHalfBlockBorder(), true, false, false, false).
+		Render(footer)
+}
+
+func (r Router) content(height int) string {
+	if r.showDebug {
+		title := fmt.Sprintf("raw data of %s screen (j/k to scroll, esc or ctrl+r to close)", r.current)
+		return lipgloss.JoinVertical(lipgloss.Left, title, "", r.debugView(height-2))
+	}
+
+	if r.showLog {
+		return lipgloss.JoinVertical(lipgloss.Left, "log (esc or ctrl+l to close)", "", r.log.View())
+	}
+
+	return r.screens[r.current].View()
 }
 
 func (r Router) View() string {
 	header := r.header()
+	footer := r.footer()
 
+	height := r.height - lipgloss.Height(header) - lipgloss.Height(footer)
 	content := lipgloss.NewStyle().
 		Width(r.width).
-		Height(r.height - lipgloss.Height(header)).
-		Render(r.screens[r.current].View())
+		Height(height).
+		Render(r.content(height))
 
-	return lipgloss.JoinVertical(lipgloss.Top, header, content)
+	return lipgloss.JoinVertical(lipgloss.Top, header, content, footer)
 }
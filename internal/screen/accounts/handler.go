@@ -0,0 +1,140 @@
+package accounts
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/ui"
+)
+
+// loadedMsg delivers saved profiles to the Screen, or error if listing them failed.
+type loadedMsg struct {
+	names   []string
+	current string
+	err     error
+}
+
+// switchedMsg signalizes that switching to profile with name failed.
+type switchedMsg struct {
+	name string
+	err  error
+}
+
+// Screen is a model of accounts screen, which switches between saved profiles.
+type Screen struct {
+	service *Service
+	names   []string
+	current string
+
+	content struct {
+		list   *ui.List
+		status *ui.Label
+
+		button struct {
+			back *ui.Button
+		}
+	}
+}
+
+// New creates new Screen from Service.
+func New(service *Service) Screen {
+	result := Screen{
+		service: service,
+	}
+
+	result.content.status = ui.NewLabel("loading profiles...")
+	result.content.button.back = ui.NewButton("Back", func() tea.Msg {
+		return screen.BackMsg{}
+	})
+
+	result.content.list = ui.NewList(result.items()...)
+	return result
+}
+
+func (Screen) ID() screen.Type {
+	return screen.TypeAccounts
+}
+
+func (s Screen) Init() tea.Cmd {
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: ui.SelectMsg{}}
+	}
+}
+
+func (s Screen) load() tea.Cmd {
+	return func() tea.Msg {
+		names, current, err := s.service.profiles()
+		return router.TargetMsg{Type: s.ID(), Inner: loadedMsg{names: names, current: current, err: err}}
+	}
+}
+
+// items returns saved profiles followed by controls.
+func (s Screen) items() []tea.Model {
+	items := make([]tea.Model, 0, len(s.names)+1)
+	for _, name := range s.names {
+		text := label(name)
+		if name == s.current {
+			text += " (current)"
+		}
+
+		items = append(items, ui.NewButton(text, func() tea.Msg {
+			user, err := s.service.switchTo(name)
+			if err != nil {
+				return router.TargetMsg{Type: screen.TypeAccounts, Inner: switchedMsg{name: name, err: err}}
+			}
+
+			// every screen reloads its data for the new user on login
+			return router.BroadcastMsg{Inner: auth.LoginMsg{User: user}}
+		}))
+	}
+
+	return append(items, s.content.button.back)
+}
+
+func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case auth.LoginMsg:
+		return s, s.load()
+	case loadedMsg:
+		if msg.err != nil {
+			s.content.status.Set(ui.ErrorStyle().Render(msg.err.Error()))
+			return s, nil
+		}
+
+		s.names, s.current = msg.names, msg.current
+		s.content.status.Set("using " + label(s.current))
+		return s, s.content.list.Set(s.items()...)
+	case switchedMsg:
+		s.content.status.Set(ui.ErrorStyle().Render(msg.err.Error()))
+		return s, func() tea.Msg {
+			return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+		}
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			return s, func() tea.Msg {
+				return screen.BackMsg{}
+			}
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
+	_, cmd := s.content.list.Update(msg)
+	return s, cmd
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left,
+		"accounts screen (run with --profile name to add another account)", s.content.status.View(), "")
+}
+
+func (s Screen) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.header(),
+		s.content.list.View(),
+	)
+}
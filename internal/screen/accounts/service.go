@@ -0,0 +1,54 @@
+package accounts
+
+import (
+	"fmt"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// Store is a subset of register.Service methods used by Service, which keeps credentials of every profile.
+type Store interface {
+	Profile() string
+	Profiles() ([]string, error)
+	Switch(name string) (*sdk.Authorization, error)
+}
+
+// Service provides logic of switching between saved profiles.
+type Service struct {
+	store Store
+}
+
+// NewService creates new Service from store.
+func NewService(store Store) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+// profiles returns names of saved profiles and name of the current one. Empty name stands for the default profile.
+func (s *Service) profiles() ([]string, string, error) {
+	names, err := s.store.Profiles()
+	if err != nil {
+		return nil, "", fmt.Errorf("accounts: failed to list profiles: %w", err)
+	}
+
+	return names, s.store.Profile(), nil
+}
+
+func (s *Service) switchTo(name string) (*sdk.Authorization, error) {
+	user, err := s.store.Switch(name)
+	if err != nil {
+		return nil, fmt.Errorf("accounts: failed to switch to %s: %w", label(name), err)
+	}
+
+	return user, nil
+}
+
+// label names profile for humans, since the default one has no name.
+func label(name string) string {
+	if name == "" {
+		return "default profile"
+	}
+
+	return "profile " + name
+}
@@ -0,0 +1,202 @@
+package edit
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/ui"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// OpenMsg asks the Screen to start editing provided details and become the current screen.
+type OpenMsg struct {
+	Details *sdk.UserDetails
+}
+
+// SavedMsg signalizes that profile was edited, so its data must be reloaded.
+type SavedMsg struct{}
+
+// submitMsg asks the Screen to save entered values.
+type submitMsg struct{}
+
+// unchangedMsg signalizes that entered values match the current details.
+type unchangedMsg struct{}
+
+// Screen is a model of profile editing screen.
+type Screen struct {
+	service *Service
+	user    *sdk.Authorization
+	details *sdk.UserDetails
+
+	content struct {
+		list   *ui.List
+		status *ui.Label
+
+		fields []*ui.Field
+		field  struct {
+			nickname    *ui.Field
+			description *ui.Field
+			interests   *ui.Field
+		}
+
+		button struct {
+			submit *ui.Button
+			back   *ui.Button
+		}
+	}
+
+	width int
+}
+
+func field(label string, limit int) *ui.Field {
+	field := textinput.New()
+	field.Placeholder = label
+	field.CharLimit = limit
+	field.Prompt = ""
+	return ui.NewField(field)
+}
+
+// New creates new Screen from Service.
+func New(service *Service) Screen {
+	result := Screen{
+		service: service,
+	}
+
+	result.content.field.nickname = field("Nickname", 256).WithCounter()
+	result.content.field.description = field("Description", 1024).WithCounter()
+	result.content.field.interests = field("Interests", 0).WithHelp("separate interests with commas")
+
+	result.content.button.submit = ui.NewButton("Save", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeEdit, Inner: submitMsg{}}
+	})
+	result.content.button.back = ui.NewButton("Back", func() tea.Msg {
+		return screen.BackMsg{}
+	})
+
+	result.content.fields = []*ui.Field{
+		result.content.field.nickname,
+		result.content.field.description,
+		result.content.field.interests,
+	}
+
+	result.content.status = ui.NewLabel("")
+	result.content.list = ui.NewList(
+		result.content.field.nickname,
+		result.content.field.description,
+		result.content.field.interests,
+		result.content.button.submit,
+		result.content.button.back)
+
+	return result
+}
+
+func (Screen) ID() screen.Type {
+	return screen.TypeEdit
+}
+
+func (s Screen) Init() tea.Cmd {
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: ui.SelectMsg{}}
+	}
+}
+
+// submit saves fields which differ from the current details.
+func (s Screen) submit() tea.Cmd {
+	if s.user == nil || s.details == nil {
+		s.content.status.Set("profile isn't loaded yet")
+		return nil
+	}
+
+	s.content.status.Set("saving...")
+	return func() tea.Msg {
+		changes, err := s.service.edit(s.user, s.details,
+			s.content.field.nickname.Value(),
+			s.content.field.description.Value(),
+			s.content.field.interests.Value())
+		if err != nil {
+			return router.TargetMsg{Type: s.ID(), Inner: screen.ErrorMsg{Value: err}}
+		}
+
+		if changes.Empty() {
+			return router.TargetMsg{Type: s.ID(), Inner: unchangedMsg{}}
+		}
+
+		return router.TargetMsg{Type: s.ID(), Inner: SavedMsg{}}
+	}
+}
+
+func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+	case auth.LoginMsg:
+		s.user = msg.User
+		return s, nil
+	case OpenMsg:
+		s.details = msg.Details
+		s.content.field.nickname.Raw().SetValue(msg.Details.Nickname.Value())
+		s.content.field.description.Raw().SetValue(msg.Details.Description.Value())
+		s.content.field.interests.Raw().SetValue(interestsString(msg.Details.Interests))
+		s.content.status.Set("")
+		return s, func() tea.Msg {
+			return screen.ChangeMsg{NewType: s.ID()}
+		}
+	case submitMsg:
+		return s, s.submit()
+	case unchangedMsg:
+		s.content.status.Set("nothing changed")
+		return s, nil
+	case SavedMsg:
+		s.content.status.Set("")
+		return s, tea.Batch(
+			func() tea.Msg {
+				return screen.BackMsg{}
+			},
+			func() tea.Msg {
+				return router.TargetMsg{Type: screen.TypeProfile, Inner: msg}
+			},
+			func() tea.Msg {
+				return router.LogMsg{Severity: router.SeveritySuccess, Text: "profile saved"}
+			})
+	case screen.ErrorMsg:
+		s.content.status.Set(msg.Value.Error())
+		return s, nil
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			return s, func() tea.Msg {
+				return screen.BackMsg{}
+			}
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
+	_, cmd := s.content.list.Update(msg)
+	return s, cmd
+}
+
+func (s Screen) Help() string {
+	return s.content.list.Help()
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left, "edit profile screen (leave a field as is to keep it)", "")
+}
+
+func (s Screen) View() string {
+	for _, field := range s.content.fields {
+		field.Raw().Width = s.width - 10
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.header(),
+		s.content.list.View(),
+		"",
+		s.content.status.View(),
+	)
+}
@@ -0,0 +1,104 @@
+package edit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/friendly-social/cli/internal/interests"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// Changes holds edited fields of user's profile. Nil fields are left unchanged.
+type Changes struct {
+	Nickname    *sdk.Nickname
+	Description *sdk.UserDescription
+	Interests   *sdk.Interests
+}
+
+// Empty reports whether Changes contain no edited fields.
+func (c Changes) Empty() bool {
+	return c.Nickname == nil && c.Description == nil && c.Interests == nil
+}
+
+// Client edits profile of the logged in user.
+type Client interface {
+	EditProfile(ctx context.Context, auth *sdk.Authorization, changes Changes) error
+}
+
+// Service provides logic of editing user's profile.
+type Service struct {
+	client Client
+}
+
+// NewService creates new Service from client.
+func NewService(client Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// interestsString joins interests the same way user enters them.
+func interestsString(interests sdk.Interests) string {
+	values := make([]string, 0, len(interests.Value()))
+	for _, interest := range interests.Value() {
+		values = append(values, interest.Value())
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// diff validates entered values and returns only those which differ from current details.
+func diff(current *sdk.UserDetails, nicknameString, descriptionString, interestsString string) (Changes, error) {
+	var changes Changes
+
+	if nicknameString != current.Nickname.Value() {
+		nickname, err := sdk.NewNickname(nicknameString)
+		if err != nil {
+			return Changes{}, fmt.Errorf("edit: failed to create nickname: %w", err)
+		}
+
+		changes.Nickname = &nickname
+	}
+
+	if descriptionString != current.Description.Value() {
+		description, err := sdk.NewUserDescription(descriptionString)
+		if err != nil {
+			return Changes{}, fmt.Errorf("edit: failed to create description: %w", err)
+		}
+
+		changes.Description = &description
+	}
+
+	interestsSlice, errs := interests.Parse(interestsString)
+	if len(errs) != 0 {
+		return Changes{}, fmt.Errorf("edit: failed to create interests: %w", errors.Join(errs...))
+	}
+
+	if !slices.Equal(interestsSlice, current.Interests.Value()) {
+		parsed, err := sdk.NewInterests(interestsSlice...)
+		if err != nil {
+			return Changes{}, fmt.Errorf("edit: failed to create interests: %w", err)
+		}
+
+		changes.Interests = &parsed
+	}
+
+	return changes, nil
+}
+
+func (s *Service) edit(user *sdk.Authorization, current *sdk.UserDetails, nickname, description, interests string) (Changes, error) {
+	changes, err := diff(current, nickname, description, interests)
+	if err != nil || changes.Empty() {
+		return changes, err
+	}
+
+	err = s.client.EditProfile(context.Background(), user, changes)
+	if err != nil {
+		return Changes{}, fmt.Errorf("edit: failed to edit profile: %w", err)
+	}
+
+	return changes, nil
+}
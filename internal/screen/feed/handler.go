@@ -0,0 +1,905 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/transport"
+	"github.com/friendly-social/cli/internal/ui"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// undoWindow is delay before friend request is actually sent, during which it can be undone.
+const undoWindow = 5 * time.Second
+
+// maxBackoff limits how many times auto refresh interval grows while the feed stays empty,
+// and how many times polling interval grows while polls keep failing.
+const maxBackoff = 8
+
+// refreshMsg asks the Screen to reload the feed.
+type refreshMsg struct{}
+
+// loadedMsg delivers freshly loaded feed entries to the Screen.
+type loadedMsg struct {
+	load      int
+	entries   []sdk.FeedEntry
+	fromCache bool
+}
+
+// failedMsg signalizes that loading of the feed failed.
+type failedMsg struct {
+	load int
+	err  error
+}
+
+// cancelledMsg signalizes that loading of the feed was cancelled.
+type cancelledMsg struct {
+	load int
+}
+
+// sendMsg asks the Screen to send friend request to user.
+type sendMsg struct {
+	details sdk.UserDetails
+}
+
+// commitMsg signalizes that undo window of friend request to user has passed. User is the one who scheduled it,
+// who may be logged out by now.
+type commitMsg struct {
+	user    *sdk.Authorization
+	details sdk.UserDetails
+}
+
+// flushedMsg signalizes that friend requests scheduled before switching account were sent, successfully if err is nil.
+type flushedMsg struct {
+	err error
+}
+
+// peekedMsg delivers the freshest details of user selected in the feed.
+type peekedMsg struct {
+	details *sdk.UserDetails
+	err     error
+}
+
+// requestedMsg signalizes that friend request to user with provided ID was sent.
+type requestedMsg struct {
+	id sdk.UserId
+}
+
+// requestFailedMsg signalizes that friend request to user with provided ID wasn't sent.
+type requestFailedMsg struct {
+	id  sdk.UserId
+	err error
+}
+
+// pollMsg asks the Screen to poll pending friend requests.
+type pollMsg struct{}
+
+// polledMsg delivers number of pending friend requests to the Screen.
+type polledMsg struct {
+	count int
+	err   error
+}
+
+// togglePollMsg asks the Screen to pause or resume polling.
+type togglePollMsg struct{}
+
+// countdownMsg counts down one second until the next auto refresh started by toggle with provided number.
+type countdownMsg struct {
+	toggle int
+}
+
+// filter chooses which feed entries are shown depending on whether they come from extended network.
+type filter int
+
+const (
+	filterAll filter = iota
+	filterDirect
+	filterExtended
+)
+
+func (f filter) String() string {
+	switch f {
+	case filterDirect:
+		return "direct only"
+	case filterExtended:
+		return "extended network only"
+	default:
+		return "all"
+	}
+}
+
+// next returns filter which follows f when cycling through them.
+func (f filter) next() filter {
+	return (f + 1) % (filterExtended + 1)
+}
+
+// allows reports whether entry is shown with the filter.
+func (f filter) allows(entry sdk.FeedEntry) bool {
+	switch f {
+	case filterDirect:
+		return !entry.IsExtendedNetwork
+	case filterExtended:
+		return entry.IsExtendedNetwork
+	default:
+		return true
+	}
+}
+
+// matches reports whether entry has interest containing query, ignoring case. Empty query matches everyone.
+func matches(entry sdk.FeedEntry, query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+
+	return slices.ContainsFunc(entry.Details.Interests.Value(), func(interest sdk.Interest) bool {
+		return strings.Contains(strings.ToLower(interest.Value()), query)
+	})
+}
+
+// order chooses how feed entries are sorted.
+type order int
+
+const (
+	orderServer order = iota
+	orderCommonFriends
+	orderNickname
+)
+
+func (o order) String() string {
+	switch o {
+	case orderCommonFriends:
+		return "common friends"
+	case orderNickname:
+		return "nickname"
+	default:
+		return "server"
+	}
+}
+
+// next returns order which follows o when cycling through them.
+func (o order) next() order {
+	return (o + 1) % (orderNickname + 1)
+}
+
+// compare sorts entries with the order, keeping server order of equal ones when used with stable sort.
+func (o order) compare(a, b sdk.FeedEntry) int {
+	switch o {
+	case orderCommonFriends:
+		return len(b.CommonFriends) - len(a.CommonFriends)
+	case orderNickname:
+		return strings.Compare(strings.ToLower(a.Details.Nickname.Value()), strings.ToLower(b.Details.Nickname.Value()))
+	default:
+		return 0
+	}
+}
+
+// Screen is a model of feed screen.
+type Screen struct {
+	service *Service
+	user    *sdk.Authorization
+	entries []sdk.FeedEntry
+	filter  filter
+	order   order
+	pending map[sdk.UserId]bool
+	last    *sdk.UserDetails
+	cancel  context.CancelFunc
+	loads   int
+	failed  bool
+
+	polling  bool
+	failures int
+	paused   bool
+	blurred  bool
+	requests int
+	counted  bool
+
+	auto      bool
+	toggles   int
+	delay     time.Duration
+	remaining time.Duration
+
+	width int
+
+	// interest is the query entries are currently filtered by, it's applied on top of filter.
+	interest string
+
+	content struct {
+		list      *ui.List
+		status    *ui.Label
+		peek      *ui.Label
+		countdown *ui.Label
+		interest  *ui.Field
+
+		button struct {
+			refresh *ui.Button
+			poll    *ui.Button
+			back    *ui.Button
+		}
+	}
+}
+
+// New creates new Screen from Service.
+func New(service *Service) Screen {
+	result := Screen{
+		service: service,
+		pending: make(map[sdk.UserId]bool),
+	}
+
+	result.content.status = ui.NewLabel("log in to see your feed")
+	result.content.peek = ui.NewLabel("")
+	result.content.countdown = ui.NewLabel("")
+	if service.refresh != 0 {
+		result.content.countdown.Set("auto refresh is off (a to turn on)")
+	}
+	result.content.button.refresh = ui.NewButton("Refresh", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeFeed, Inner: refreshMsg{}}
+	})
+	result.content.button.poll = ui.NewButton("Pause polling", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeFeed, Inner: togglePollMsg{}}
+	})
+	result.content.button.back = ui.NewButton("Back", func() tea.Msg {
+		return screen.BackMsg{}
+	})
+
+	interest := textinput.New()
+	interest.Placeholder = "Filter by interest (c to clear)"
+	interest.Prompt = "/ "
+	result.content.interest = ui.NewField(interest)
+
+	result.content.list = ui.NewList(result.items()...)
+
+	return result
+}
+
+func (Screen) ID() screen.Type {
+	return screen.TypeFeed
+}
+
+func (s Screen) Init() tea.Cmd {
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: ui.SelectMsg{}}
+	}
+}
+
+// load starts loading of the feed, which can be cancelled until Screen receives its result.
+// Previous load is cancelled and its result is ignored.
+func (s Screen) load() (Screen, tea.Cmd) {
+	if s.user == nil {
+		return s, nil
+	}
+
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.loads++
+	load := s.loads
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	state, _ := ui.ListState(len(s.entries), nil, true, "")
+	s.content.status.Set(state + " (esc to cancel)")
+
+	return s, func() tea.Msg {
+		entries, fromCache, err := s.service.queue(ctx, s.user)
+		if errors.Is(err, context.Canceled) {
+			return router.TargetMsg{Type: s.ID(), Inner: cancelledMsg{load: load}}
+		}
+
+		if err != nil {
+			return router.TargetMsg{Type: s.ID(), Inner: failedMsg{load: load, err: err}}
+		}
+
+		return router.TargetMsg{Type: s.ID(), Inner: loadedMsg{load: load, entries: entries, fromCache: fromCache}}
+	}
+}
+
+// schedule sends friend request to user after undo window, unless previous one to them is still pending.
+func (s Screen) schedule(details sdk.UserDetails) (Screen, tea.Cmd) {
+	if s.pending[details.Id] {
+		return s, nil
+	}
+
+	s.pending[details.Id] = true
+	s.service.delay(s.user, details)
+	s.last = &details
+	s.content.status.Set(fmt.Sprintf("sending friend request to %s in %s... (u to undo)",
+		details.Nickname.Value(), undoWindow))
+
+	user := s.user
+	return s, clock.Tick(s.service.clock, undoWindow, func(time.Time) tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: commitMsg{user: user, details: details}}
+	})
+}
+
+// peek fetches details of the selected entry to show them below the feed.
+func (s Screen) peek() tea.Cmd {
+	entry, ok := s.cursorEntry()
+	if s.user == nil || !ok {
+		return nil
+	}
+
+	details := entry.Details
+	s.content.peek.Set(fmt.Sprintf("loading %s...", details.Nickname.Value()))
+
+	return func() tea.Msg {
+		result, err := s.service.details(s.user, details)
+		return router.TargetMsg{Type: s.ID(), Inner: peekedMsg{details: result, err: err}}
+	}
+}
+
+// undo cancels the last scheduled friend request if its undo window hasn't passed yet.
+func (s Screen) undo() (Screen, tea.Cmd) {
+	if s.last == nil {
+		return s, nil
+	}
+
+	delete(s.pending, s.last.Id)
+	s.service.take(s.last.Id)
+	text := fmt.Sprintf("friend request to %s undone", s.last.Nickname.Value())
+	s.last = nil
+	s.content.status.Set(text)
+
+	return s, func() tea.Msg {
+		return router.LogMsg{Severity: router.SeverityInfo, Text: text}
+	}
+}
+
+// request sends friend request from user to the one whose undo window has passed.
+func (s Screen) request(user *sdk.Authorization, details sdk.UserDetails) tea.Cmd {
+	s.content.status.Set(fmt.Sprintf("sending friend request to %s...", details.Nickname.Value()))
+
+	return func() tea.Msg {
+		err := s.service.request(user, details)
+		if err != nil {
+			return router.TargetMsg{Type: s.ID(), Inner: requestFailedMsg{id: details.Id, err: err}}
+		}
+
+		return router.TargetMsg{Type: s.ID(), Inner: requestedMsg{id: details.Id}}
+	}
+}
+
+// visible returns entries allowed by the current filter, sorted in the same order as they're listed.
+func (s Screen) visible() []sdk.FeedEntry {
+	visible := make([]sdk.FeedEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		if s.filter.allows(entry) && matches(entry, s.interest) {
+			visible = append(visible, entry)
+		}
+	}
+
+	slices.SortStableFunc(visible, s.order.compare)
+	return visible
+}
+
+// cursorEntry returns entry under cursor, which is false if cursor is on the interest filter or on controls.
+func (s Screen) cursorEntry() (sdk.FeedEntry, bool) {
+	// the interest filter comes before entries
+	index, visible := s.content.list.Cursor()-1, s.visible()
+	if index < 0 || index >= len(visible) {
+		return sdk.FeedEntry{}, false
+	}
+
+	return visible[index], true
+}
+
+// selected returns ID of the entry under cursor or nil if cursor isn't on an entry.
+func (s Screen) selected() *sdk.UserId {
+	entry, ok := s.cursorEntry()
+	if !ok {
+		return nil
+	}
+
+	return &entry.Details.Id
+}
+
+// relist rebuilds the list after entries, filters or order change, keeping cursor on entry with provided ID.
+// Cursor ends up on the interest filter if there's no such entry.
+func (s Screen) relist(selected *sdk.UserId) tea.Cmd {
+	cmd := s.content.list.Set(s.items()...)
+	if selected == nil {
+		return cmd
+	}
+
+	index := slices.IndexFunc(s.visible(), func(entry sdk.FeedEntry) bool {
+		return entry.Details.Id == *selected
+	})
+	if index < 0 {
+		return cmd
+	}
+
+	return tea.Batch(cmd, s.content.list.Select(index+1))
+}
+
+// items returns the interest filter, visible entries and controls.
+func (s Screen) items() []tea.Model {
+	visible := s.visible()
+	items := make([]tea.Model, 0, len(visible)+4)
+	items = append(items, s.content.interest)
+	for _, entry := range visible {
+		prefix := fmt.Sprintf("%s %s: ",
+			ui.Avatar(entry.Details.Nickname.Value(), entry.Details.Avatar != nil), entry.Details.Nickname.Value())
+		suffix := fmt.Sprintf(" (%d common friends)", len(entry.CommonFriends))
+		if entry.IsRequest {
+			suffix += " [wants to be your friend]"
+		}
+
+		title := ui.Clamp(prefix, entry.Details.Description.Value(), suffix, s.width-ui.ListIndent, ui.SummaryLines)
+
+		items = append(items, ui.NewButton(title, func() tea.Msg {
+			return router.TargetMsg{Type: s.ID(), Inner: sendMsg{details: entry.Details}}
+		}))
+	}
+
+	return append(items, s.controls()...)
+}
+
+// controls returns buttons shown below feed entries.
+func (s Screen) controls() []tea.Model {
+	if s.service.poll == 0 {
+		return []tea.Model{s.content.button.refresh, s.content.button.back}
+	}
+
+	return []tea.Model{s.content.button.refresh, s.content.button.poll, s.content.button.back}
+}
+
+// toggleAuto turns automatic refreshing on or off.
+func (s Screen) toggleAuto() (Screen, tea.Cmd) {
+	if s.service.refresh == 0 {
+		return s, nil
+	}
+
+	s.auto = !s.auto
+	s.toggles++
+	if !s.auto {
+		s.content.countdown.Set("auto refresh is off (a to turn on)")
+		return s, nil
+	}
+
+	s.delay = s.service.refresh
+	s.remaining = s.delay
+	s.content.countdown.Set(fmt.Sprintf("next refresh in %s (a to turn off)", s.remaining))
+	return s, s.countdown()
+}
+
+// countdown schedules the next second of countdown to auto refresh.
+func (s Screen) countdown() tea.Cmd {
+	toggle := s.toggles
+	return clock.Tick(s.service.clock, time.Second, func(time.Time) tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: countdownMsg{toggle: toggle}}
+	})
+}
+
+// backoff grows auto refresh interval while the feed stays empty and resets it once there are entries.
+func (s Screen) backoff() Screen {
+	if len(s.entries) != 0 {
+		s.delay = s.service.refresh
+	} else {
+		s.delay = min(s.delay*2, s.service.refresh*maxBackoff)
+	}
+
+	s.remaining = s.delay
+	return s
+}
+
+// retry returns delay before the next poll, which doubles with every failed poll in a row.
+// Jitter spreads retries of clients which lost the server at the same time.
+func (s Screen) retry() time.Duration {
+	if s.failures == 0 {
+		return s.service.poll
+	}
+
+	delay := s.service.poll
+	for i := 0; i < s.failures && delay < s.service.poll*maxBackoff; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, s.service.poll*maxBackoff)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// tick schedules the next poll of pending friend requests after delay.
+func (s Screen) tick(delay time.Duration) tea.Cmd {
+	return clock.Tick(s.service.clock, delay, func(time.Time) tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: pollMsg{}}
+	})
+}
+
+// poll counts pending friend requests unless polling is paused or terminal is unfocused.
+func (s Screen) poll() tea.Cmd {
+	if s.paused || s.blurred || s.user == nil {
+		return s.tick(s.service.poll)
+	}
+
+	return func() tea.Msg {
+		count, err := s.service.requests(context.Background(), s.user)
+		return router.TargetMsg{Type: s.ID(), Inner: polledMsg{count: count, err: err}}
+	}
+}
+
+// count records number of pending friend requests and reports it to status bar.
+func (s Screen) count(count int) (Screen, tea.Cmd) {
+	increased := s.counted && count > s.requests
+	s.requests = count
+	s.counted = true
+
+	cmds := []tea.Cmd{func() tea.Msg {
+		return router.RequestsMsg{Count: count, Increased: increased}
+	}}
+	if increased {
+		cmds = append(cmds, func() tea.Msg {
+			return router.LogMsg{Severity: router.SeveritySuccess, Text: "you have new friend requests"}
+		})
+	}
+
+	return s, tea.Batch(cmds...)
+}
+
+// interests joins user's interests into a single line.
+func interests(value sdk.Interests) string {
+	values := make([]string, 0, len(value.Value()))
+	for _, interest := range value.Value() {
+		values = append(values, interest.Value())
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// requestsIn returns number of feed entries which are friend requests.
+func requestsIn(entries []sdk.FeedEntry) int {
+	count := 0
+	for _, entry := range entries {
+		if entry.IsRequest {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		if selected := s.selected(); selected != nil {
+			return s, s.relist(selected)
+		}
+
+		return s, s.content.list.Replace(s.items()...)
+	case auth.LoginMsg:
+		var flush tea.Cmd
+		if s.user != nil && msg.User != nil && s.user.Id != msg.User.Id {
+			// requests of the previous account are sent right away, since undoing them needs its feed
+			s.pending = make(map[sdk.UserId]bool)
+			s.last = nil
+			flush = func() tea.Msg {
+				return router.TargetMsg{Type: screen.TypeFeed, Inner: flushedMsg{err: s.service.Flush()}}
+			}
+		}
+
+		s.user = msg.User
+
+		var cmd tea.Cmd
+		s, cmd = s.load()
+		if s.service.poll == 0 || s.polling {
+			return s, tea.Batch(cmd, flush)
+		}
+
+		s.polling = true
+		return s, tea.Batch(cmd, flush, s.tick(s.service.poll))
+	case flushedMsg:
+		if msg.err != nil {
+			return s, func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+			}
+		}
+
+		return s, nil
+	case pollMsg:
+		return s, s.poll()
+	case polledMsg:
+		if msg.err != nil {
+			s.failures++
+			// server asking to wait longer, for example during maintenance, isn't polled earlier
+			retry := max(s.retry(), transport.RetryAfter(msg.err))
+			return s, tea.Batch(s.tick(retry), func() tea.Msg {
+				return router.PollingMsg{Failures: s.failures, Retry: retry}
+			}, func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+			})
+		}
+
+		cmds := []tea.Cmd{s.tick(s.service.poll)}
+		if s.failures != 0 {
+			s.failures = 0
+			cmds = append(cmds, func() tea.Msg {
+				return router.PollingMsg{}
+			})
+		}
+
+		var cmd tea.Cmd
+		s, cmd = s.count(msg.count)
+		return s, tea.Batch(append(cmds, cmd)...)
+	case togglePollMsg:
+		s.paused = !s.paused
+		if s.paused {
+			s.content.button.poll.SetTitle("Resume polling")
+		} else {
+			s.content.button.poll.SetTitle("Pause polling")
+		}
+
+		return s, nil
+	case tea.FocusMsg:
+		s.blurred = false
+		return s, nil
+	case tea.BlurMsg:
+		s.blurred = true
+		return s, nil
+	case refreshMsg:
+		return s.load()
+	case loadedMsg:
+		if msg.load != s.loads {
+			return s, nil
+		}
+
+		// keep the same entry selected, so refresh doesn't move user's cursor
+		selected := s.selected()
+
+		s.cancel = nil
+		s.failed = false
+		s.entries = msg.entries
+		status := s.summary()
+		if msg.fromCache {
+			status += " (cached)"
+		}
+		s.content.status.Set(status)
+		if s.auto {
+			s = s.backoff()
+		}
+
+		var cmd tea.Cmd
+		s, cmd = s.count(requestsIn(s.entries))
+		return s, tea.Batch(cmd, s.relist(selected))
+	case countdownMsg:
+		if !s.auto || msg.toggle != s.toggles {
+			return s, nil
+		}
+
+		s.remaining = max(s.remaining-time.Second, 0)
+		if s.remaining > 0 || s.cancel != nil {
+			s.content.countdown.Set(fmt.Sprintf("next refresh in %s (a to turn off)", s.remaining))
+			return s, s.countdown()
+		}
+
+		s.remaining = s.delay
+		s.content.countdown.Set("refreshing... (a to turn off)")
+
+		var cmd tea.Cmd
+		s, cmd = s.load()
+		return s, tea.Batch(cmd, s.countdown())
+	case sendMsg:
+		return s.schedule(msg.details)
+	case commitMsg:
+		if !s.service.take(msg.details.Id) {
+			return s, nil
+		}
+
+		if s.last != nil && s.last.Id == msg.details.Id {
+			s.last = nil
+		}
+
+		return s, s.request(msg.user, msg.details)
+	case requestedMsg:
+		delete(s.pending, msg.id)
+		sent := func(entry sdk.FeedEntry) bool {
+			return entry.Details.Id == msg.id
+		}
+		index, listed := slices.IndexFunc(s.entries, sent), slices.IndexFunc(s.visible(), sent)
+		if index < 0 {
+			return s, nil
+		}
+
+		text := fmt.Sprintf("friend request sent to %s", s.entries[index].Details.Nickname.Value())
+		s.content.status.Set(text)
+		s.entries = slices.Delete(s.entries, index, index+1)
+
+		log := func() tea.Msg {
+			return router.LogMsg{Severity: router.SeveritySuccess, Text: text}
+		}
+		if listed < 0 {
+			return s, log
+		}
+
+		// the interest filter comes before entries
+		return s, tea.Batch(s.content.list.Remove(listed+1), log)
+	case requestFailedMsg:
+		delete(s.pending, msg.id)
+		s.content.status.Set(ui.ErrorStyle().Render(msg.err.Error()))
+		return s, func() tea.Msg {
+			return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+		}
+	case cancelledMsg:
+		if msg.load != s.loads {
+			return s, nil
+		}
+
+		s.cancel = nil
+		s.content.status.Set("loading cancelled")
+		return s, tea.Batch(
+			func() tea.Msg {
+				return screen.BackMsg{}
+			},
+			func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityInfo, Text: "feed loading cancelled"}
+			})
+	case failedMsg:
+		if msg.load != s.loads {
+			return s, nil
+		}
+
+		s.cancel = nil
+		s.failed = true
+		state, _ := ui.ListState(len(s.entries), msg.err, false, "")
+		s.content.status.Set(state)
+		return s, func() tea.Msg {
+			return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+		}
+	case peekedMsg:
+		if msg.err != nil {
+			s.content.peek.Set(msg.err.Error())
+			return s, nil
+		}
+
+		text := fmt.Sprintf("nickname: %s\ndescription: %s\ninterests: %s\nsocial link: %s",
+			msg.details.Nickname.Value(), msg.details.Description.Value(),
+			interests(msg.details.Interests), msg.details.SocialLink.Value())
+
+		index := slices.IndexFunc(s.entries, func(entry sdk.FeedEntry) bool {
+			return entry.Details.Id == msg.details.Id
+		})
+		if index < 0 {
+			s.content.peek.Set(text)
+			return s, nil
+		}
+
+		fields := changed(s.entries[index].Details, *msg.details)
+		if len(fields) == 0 {
+			s.content.peek.Set(text)
+			return s, nil
+		}
+
+		// the feed shows the same fresh details as the peek, keeping the user selected
+		s.content.peek.Set(text + "\nupdated since the feed was loaded: " + strings.Join(fields, ", "))
+		s.entries[index].Details = *msg.details
+		return s, s.relist(s.selected())
+	case tea.KeyMsg:
+		// keys typed into the interest filter aren't shortcuts
+		if s.content.interest.Raw().Focused() {
+			break
+		}
+
+		switch msg.String() {
+		case "c":
+			if s.interest == "" {
+				return s, nil
+			}
+
+			selected := s.selected()
+			s.interest = ""
+			s.content.interest.Raw().SetValue("")
+			s.content.status.Set(s.summary())
+			return s, s.relist(selected)
+		case "u":
+			return s.undo()
+		case "v":
+			return s, s.peek()
+		case "a":
+			return s.toggleAuto()
+		case "e":
+			selected := s.selected()
+			s.filter = s.filter.next()
+			s.content.status.Set(s.summary())
+			return s, s.relist(selected)
+		case "s":
+			selected := s.selected()
+			s.order = s.order.next()
+			return s, s.relist(selected)
+		case "r":
+			if s.failed {
+				return s.load()
+			}
+		case "esc":
+			if s.cancel == nil {
+				return s, func() tea.Msg {
+					return screen.BackMsg{}
+				}
+			}
+		}
+
+		if s.cancel != nil && (msg.String() == "esc" || msg.String() == "ctrl+c") {
+			s.cancel()
+			return s, nil
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
+	_, cmd := s.content.list.Update(msg)
+	if s.content.interest.Value() == s.interest {
+		return s, cmd
+	}
+
+	// interest filter changed while typing, so entries are narrowed down keeping the filter selected
+	s.interest = s.content.interest.Value()
+	s.content.status.Set(s.summary())
+	return s, tea.Batch(cmd, s.relist(nil))
+}
+
+func (s Screen) Debug() any {
+	return s.entries
+}
+
+// summary describes how many entries the feed has and how many of them pass the filter.
+func (s Screen) summary() string {
+	empty := "your feed is empty, check back later"
+	filtered := s.filter != filterAll || strings.TrimSpace(s.interest) != ""
+	if filtered {
+		empty = fmt.Sprintf("none of %d people in your feed match the filter", len(s.entries))
+	}
+
+	if state, ok := ui.ListState(len(s.visible()), nil, false, empty); !ok {
+		return state
+	}
+
+	if !filtered {
+		return fmt.Sprintf("%d people in your feed", len(s.entries))
+	}
+
+	return fmt.Sprintf("%d of %d people in your feed", len(s.visible()), len(s.entries))
+}
+
+func (Screen) Bindings() []ui.Binding {
+	return []ui.Binding{
+		{Key: "v", Action: "view details of selected user"},
+		{Key: "e", Action: "change filter"},
+		{Key: "s", Action: "change sort order"},
+		{Key: "a", Action: "toggle auto refresh"},
+		{Key: "u", Action: "undo the last friend request"},
+		{Key: "c", Action: "clear interest filter"},
+		{Key: "r", Action: "retry failed loading"},
+		{Key: "esc", Action: "cancel loading"},
+	}
+}
+
+func (s Screen) header() string {
+	title := fmt.Sprintf("feed screen (v to view selected user, e to change filter: %s, s to sort by: %s)", s.filter, s.order)
+	return lipgloss.JoinVertical(lipgloss.Left, ui.Wrap(title, s.width), ui.Wrap(s.content.status.View(), s.width), "")
+}
+
+func (s Screen) View() string {
+	s.content.interest.Raw().Width = s.width - 10
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.header(),
+		s.content.list.View(),
+		"",
+		// wrapped on render, so resizing the terminal rewraps them
+		ui.Wrap(s.content.peek.View(), s.width),
+		ui.Wrap(s.content.countdown.View(), s.width),
+	)
+}
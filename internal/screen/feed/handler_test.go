@@ -0,0 +1,220 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/demo"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/screen/screentest"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// recorder remembers who sent friend requests through demo client and counts feed requests, failing them with fail.
+type recorder struct {
+	*demo.Client
+
+	mu      sync.Mutex
+	senders []int64
+	queues  int
+	fail    error
+}
+
+func (r *recorder) GetFeedQueue(ctx context.Context, auth *sdk.Authorization) (*sdk.FeedQueue, error) {
+	r.mu.Lock()
+	r.queues++
+	fail := r.fail
+	r.mu.Unlock()
+
+	if fail != nil {
+		return nil, fail
+	}
+
+	return r.Client.GetFeedQueue(ctx, auth)
+}
+
+func (r *recorder) counted() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.queues
+}
+
+func (r *recorder) failWith(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fail = err
+}
+
+func (r *recorder) SendFriendRequest(ctx context.Context, auth *sdk.Authorization, userId sdk.UserId, accessHash sdk.UserAccessHash) error {
+	r.mu.Lock()
+	r.senders = append(r.senders, auth.Id.Value())
+	r.mu.Unlock()
+
+	return r.Client.SendFriendRequest(ctx, auth, userId, accessHash)
+}
+
+func (r *recorder) sent() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]int64(nil), r.senders...)
+}
+
+// start logs user with id in to the feed driven by fake clock, polling requests every poll unless it's zero.
+func start(t *testing.T, client Client, id int64, poll time.Duration) (*screentest.Driver, *clock.Fake, *Service) {
+	t.Helper()
+
+	fake := clock.NewFake(time.Time{})
+	service := NewService(client).WithClock(fake).WithPoll(poll)
+	driver := screentest.New(New(service), fake)
+	driver.Send(tea.WindowSizeMsg{Width: 80, Height: 24}, auth.LoginMsg{User: &sdk.Authorization{Id: sdk.NewUserId(id)}})
+	return driver, fake, service
+}
+
+// entry returns details of the first user in the demo feed.
+func entry(t *testing.T) sdk.UserDetails {
+	t.Helper()
+
+	feed, err := demo.NewClient().GetFeedQueue(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return feed.Entries[0].Details
+}
+
+func TestScreen_View(t *testing.T) {
+	// fake clock never fires on its own, so polling doesn't change the view
+	driver, _, _ := start(t, demo.NewClient(), 1, 0)
+	screentest.Golden(t, "loaded", driver.View())
+}
+
+func TestScreen_SwitchAccount(t *testing.T) {
+	client := &recorder{Client: demo.NewClient()}
+	driver, _, _ := start(t, client, 1, 0)
+
+	driver.Send(sendMsg{details: entry(t)})
+	driver.Send(auth.LoginMsg{User: &sdk.Authorization{Id: sdk.NewUserId(2)}})
+
+	// request scheduled before switching is sent right away, from the account which scheduled it
+	if sent := client.sent(); len(sent) != 1 || sent[0] != 1 {
+		t.Fatalf("expected a request from user 1, got requests from %v", sent)
+	}
+
+	driver.Advance(undoWindow)
+	if sent := client.sent(); len(sent) != 1 {
+		t.Fatalf("expected the request to be sent once, got requests from %v", sent)
+	}
+}
+
+func TestService_Flush(t *testing.T) {
+	client := &recorder{Client: demo.NewClient()}
+	driver, _, service := start(t, client, 1, 0)
+
+	driver.Send(sendMsg{details: entry(t)})
+	if err := service.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if sent := client.sent(); len(sent) != 1 || sent[0] != 1 {
+		t.Fatalf("expected quitting to send the request from user 1, got requests from %v", sent)
+	}
+}
+
+func TestScreen_UndoWindow(t *testing.T) {
+	client := &recorder{Client: demo.NewClient()}
+	driver, _, _ := start(t, client, 1, 0)
+
+	driver.Send(sendMsg{details: entry(t)})
+	driver.Advance(undoWindow - time.Second)
+	if sent := client.sent(); len(sent) != 0 {
+		t.Fatalf("expected nothing to be sent within undo window, got requests from %v", sent)
+	}
+
+	driver.Advance(time.Second)
+	if sent := client.sent(); len(sent) != 1 {
+		t.Fatalf("expected the request to be sent once undo window passed, got requests from %v", sent)
+	}
+}
+
+func TestScreen_Undo(t *testing.T) {
+	client := &recorder{Client: demo.NewClient()}
+	driver, _, _ := start(t, client, 1, 0)
+
+	driver.Send(sendMsg{details: entry(t)}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	driver.Advance(undoWindow)
+	if sent := client.sent(); len(sent) != 0 {
+		t.Fatalf("expected undone request not to be sent, got requests from %v", sent)
+	}
+}
+
+func TestScreen_Polling(t *testing.T) {
+	client := &recorder{Client: demo.NewClient()}
+	driver, _, _ := start(t, client, 1, 30*time.Second)
+
+	// the first request loads the feed, polls follow every 30 seconds
+	for i, advance := range []time.Duration{29 * time.Second, time.Second, 30 * time.Second} {
+		driver.Advance(advance)
+		if want := []int{1, 2, 3}[i]; client.counted() != want {
+			t.Fatalf("expected %d feed requests after advancing by %s, got %d", want, advance, client.counted())
+		}
+	}
+}
+
+// polling returns the last PollingMsg sent to the router.
+func polling(t *testing.T, driver *screentest.Driver) router.PollingMsg {
+	t.Helper()
+
+	skipped := driver.Skipped()
+	for i := len(skipped) - 1; i >= 0; i-- {
+		if msg, ok := skipped[i].(router.PollingMsg); ok {
+			return msg
+		}
+	}
+
+	t.Fatal("expected PollingMsg to be sent")
+	return router.PollingMsg{}
+}
+
+func TestScreen_PollingBackoff(t *testing.T) {
+	poll := 30 * time.Second
+	client := &recorder{Client: demo.NewClient()}
+	driver, _, _ := start(t, client, 1, poll)
+
+	client.failWith(errors.New("connection refused"))
+	driver.Advance(poll)
+
+	// every failure doubles the delay, jitter keeps it between half of it and all of it
+	for failures := 1; failures <= 3; failures++ {
+		msg := polling(t, driver)
+		delay := poll << failures
+		if msg.Failures != failures || msg.Retry < delay/2 || msg.Retry > delay {
+			t.Fatalf("expected failure %d to retry within %s and %s, got %+v", failures, delay/2, delay, msg)
+		}
+
+		polls := client.counted()
+		driver.Advance(msg.Retry - time.Nanosecond)
+		if client.counted() != polls {
+			t.Fatalf("expected no poll before %s passed", msg.Retry)
+		}
+
+		driver.Advance(time.Nanosecond)
+		if client.counted() != polls+1 {
+			t.Fatalf("expected poll once %s passed", msg.Retry)
+		}
+	}
+
+	client.failWith(nil)
+	driver.Advance(polling(t, driver).Retry)
+	if msg := polling(t, driver); msg.Failures != 0 {
+		t.Fatalf("expected successful poll to reset failures, got %+v", msg)
+	}
+}
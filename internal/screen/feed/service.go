@@ -0,0 +1,180 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/transport"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// Client is a subset of sdk.Client methods used by Service.
+type Client interface {
+	GetFeedQueue(ctx context.Context, auth *sdk.Authorization) (*sdk.FeedQueue, error)
+	SendFriendRequest(ctx context.Context, auth *sdk.Authorization, userId sdk.UserId, accessHash sdk.UserAccessHash) error
+	GetUserDetails(ctx context.Context, auth *sdk.Authorization, userId sdk.UserId, accessHash sdk.UserAccessHash) (*sdk.UserDetails, error)
+}
+
+// delayed is a friend request waiting for its undo window to pass.
+type delayed struct {
+	user    *sdk.Authorization
+	details sdk.UserDetails
+}
+
+// Service provides logic of retrieving feed and reacting to its entries.
+type Service struct {
+	client  Client
+	clock   clock.Clock
+	poll    time.Duration
+	refresh time.Duration
+
+	mu      sync.Mutex
+	delayed map[sdk.UserId]delayed
+}
+
+// NewService creates new Service from client.
+func NewService(client Client) *Service {
+	return &Service{
+		client:  client,
+		clock:   clock.Real{},
+		delayed: make(map[sdk.UserId]delayed),
+	}
+}
+
+// WithClock sets clock driving undo window, polling and auto refresh.
+func (s *Service) WithClock(c clock.Clock) *Service {
+	s.clock = c
+	return s
+}
+
+// WithPoll sets interval of polling pending friend requests. Zero interval disables polling.
+func (s *Service) WithPoll(interval time.Duration) *Service {
+	s.poll = interval
+	return s
+}
+
+// WithAutoRefresh sets base interval of automatic feed refreshing, which user can toggle on the feed screen.
+// Zero interval disables the toggle.
+func (s *Service) WithAutoRefresh(interval time.Duration) *Service {
+	s.refresh = interval
+	return s
+}
+
+// queue fetches feed entries, reporting whether they were served from cache enabled by --cache.
+func (s *Service) queue(ctx context.Context, user *sdk.Authorization) ([]sdk.FeedEntry, bool, error) {
+	ctx, fromCache := transport.TrackCache(ctx)
+	feed, err := s.client.GetFeedQueue(ctx, user)
+	if err != nil {
+		return nil, false, fmt.Errorf("feed: failed to get feed queue: %w", err)
+	}
+
+	return feed.Entries, fromCache.Load(), nil
+}
+
+// details fetches the freshest version of user's details, explaining common API errors.
+// Details embedded in feed entries are a snapshot taken when the feed was loaded, see changed for what may differ.
+func (s *Service) details(user *sdk.Authorization, details sdk.UserDetails) (*sdk.UserDetails, error) {
+	result, err := s.client.GetUserDetails(context.Background(), user, details.Id, details.AccessHash)
+
+	var apiErr sdk.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusNotFound:
+			return nil, fmt.Errorf("feed: %s no longer exists", details.Nickname.Value())
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return nil, fmt.Errorf("feed: not allowed to view %s", details.Nickname.Value())
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("feed: failed to get user details: %w", err)
+	}
+
+	return result, nil
+}
+
+// changed returns names of fields which differ between details from a feed entry and fresh ones.
+// Id and access hash identify the user, so only profile fields which the user can edit may change.
+func changed(snapshot, fresh sdk.UserDetails) []string {
+	fields := make([]string, 0)
+	if snapshot.Nickname != fresh.Nickname {
+		fields = append(fields, "nickname")
+	}
+
+	if snapshot.Description != fresh.Description {
+		fields = append(fields, "description")
+	}
+
+	if !reflect.DeepEqual(snapshot.Interests, fresh.Interests) {
+		fields = append(fields, "interests")
+	}
+
+	if !reflect.DeepEqual(snapshot.Avatar, fresh.Avatar) {
+		fields = append(fields, "avatar")
+	}
+
+	if snapshot.SocialLink != fresh.SocialLink {
+		fields = append(fields, "social link")
+	}
+
+	return fields
+}
+
+// requests returns number of users who sent friend request to user.
+func (s *Service) requests(ctx context.Context, user *sdk.Authorization) (int, error) {
+	entries, _, err := s.queue(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+
+	return requestsIn(entries), nil
+}
+
+// delay remembers friend request from user, which is sent once its undo window passes or by Flush.
+func (s *Service) delay(user *sdk.Authorization, details sdk.UserDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.delayed[details.Id] = delayed{user: user, details: details}
+}
+
+// take forgets delayed friend request to user with id, reporting whether it was still delayed.
+func (s *Service) take(id sdk.UserId) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.delayed[id]
+	delete(s.delayed, id)
+	return ok
+}
+
+// Flush sends every friend request whose undo window hasn't passed yet, each from the user who scheduled it.
+// It's meant to be called on exit, so quitting doesn't drop scheduled requests.
+func (s *Service) Flush() error {
+	s.mu.Lock()
+	requests := s.delayed
+	s.delayed = make(map[sdk.UserId]delayed)
+	s.mu.Unlock()
+
+	errs := make([]error, 0)
+	for _, request := range requests {
+		errs = append(errs, s.request(request.user, request.details))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Service) request(user *sdk.Authorization, details sdk.UserDetails) error {
+	err := s.client.SendFriendRequest(context.Background(), user, details.Id, details.AccessHash)
+	if err != nil {
+		return fmt.Errorf("feed: failed to send friend request: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package friend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/share"
+	"github.com/friendly-social/cli/internal/ui"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// checkMsg asks the Screen to look up the user before adding them.
+type checkMsg struct{}
+
+// checkedMsg delivers result of looking up the user to the Screen.
+type checkedMsg struct {
+	candidate candidate
+	details   *sdk.UserDetails
+	err       error
+}
+
+// addMsg asks the Screen to add the checked user.
+type addMsg struct{}
+
+// addedMsg signalizes that adding of the friend finished, successfully if err is nil.
+type addedMsg struct {
+	err error
+}
+
+// candidate is a user which can be added once they're confirmed.
+type candidate struct {
+	token sdk.FriendToken
+	id    sdk.UserId
+}
+
+// Screen is a model of adding friend screen.
+type Screen struct {
+	service *Service
+	user    *sdk.Authorization
+
+	// checked is the user confirmed by the last check, adding is allowed only for them.
+	checked *candidate
+
+	content struct {
+		list    *ui.List
+		status  *ui.Label
+		details *ui.Label
+
+		field *ui.Field
+
+		button struct {
+			check *ui.Button
+			add   *ui.Button
+			back  *ui.Button
+		}
+	}
+
+	width int
+}
+
+func field(label string, limit int) *ui.Field {
+	field := textinput.New()
+	field.Placeholder = label
+	field.CharLimit = limit
+	field.Prompt = ""
+	return ui.NewField(field)
+}
+
+// New creates new Screen from Service.
+func New(service *Service) Screen {
+	result := Screen{
+		service: service,
+	}
+
+	result.content.field = field("Share Link", 0)
+
+	result.content.button.check = ui.NewButton("Check", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeFriend, Inner: checkMsg{}}
+	})
+	result.content.button.add = ui.NewButton("Add", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeFriend, Inner: addMsg{}}
+	})
+	result.content.button.back = ui.NewButton("Back", func() tea.Msg {
+		return screen.BackMsg{}
+	})
+
+	result.content.status = ui.NewLabel("")
+	result.content.details = ui.NewLabel("")
+	result.content.list = ui.NewList(
+		result.content.field,
+		result.content.button.check,
+		result.content.button.add,
+		result.content.button.back)
+
+	return result
+}
+
+func (Screen) ID() screen.Type {
+	return screen.TypeFriend
+}
+
+func (s Screen) Init() tea.Cmd {
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: ui.SelectMsg{}}
+	}
+}
+
+// check looks up the entered user, so they can be confirmed before adding.
+func (s Screen) check() tea.Cmd {
+	if s.user == nil {
+		s.content.status.Set("log in to add friends")
+		return nil
+	}
+
+	// show the link as it's parsed, with whitespace added by terminal removed
+	s.content.field.Raw().SetValue(share.Clean(s.content.field.Value()))
+	token, id, err := parse(s.content.field.Value())
+	if err != nil {
+		s.content.status.Set(err.Error())
+		return nil
+	}
+
+	s.content.status.Set("looking up user...")
+	return func() tea.Msg {
+		details, err := s.service.lookup(context.Background(), s.user, id)
+		return router.TargetMsg{Type: s.ID(), Inner: checkedMsg{
+			candidate: candidate{token: token, id: id},
+			details:   details,
+			err:       err,
+		}}
+	}
+}
+
+// add adds the checked user unless entered link changed since the check.
+func (s Screen) add() tea.Cmd {
+	token, id, err := parse(s.content.field.Value())
+	if err != nil || s.checked == nil || *s.checked != (candidate{token: token, id: id}) {
+		s.content.status.Set("check the user before adding them")
+		return nil
+	}
+
+	s.content.status.Set("adding friend...")
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: addedMsg{err: s.service.add(s.user, token, id)}}
+	}
+}
+
+func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+	case auth.LoginMsg:
+		s.user = msg.User
+		return s, nil
+	case checkMsg:
+		s.checked = nil
+		s.content.details.Set("")
+		return s, s.check()
+	case checkedMsg:
+		if errors.Is(msg.err, errUnknownUser) {
+			s.checked = &msg.candidate
+			s.content.details.Set(fmt.Sprintf("user %d: %s", msg.candidate.id.Value(), msg.err.Error()))
+			s.content.status.Set("make sure the link came from the right person, then press Add to confirm")
+			return s, nil
+		}
+
+		if msg.err != nil {
+			s.content.status.Set(msg.err.Error())
+			return s, nil
+		}
+
+		s.checked = &msg.candidate
+		s.content.details.Set(fmt.Sprintf("nickname: %s\ndescription: %s",
+			msg.details.Nickname.Value(), msg.details.Description.Value()))
+		s.content.status.Set("press Add to confirm")
+		return s, nil
+	case addMsg:
+		return s, s.add()
+	case addedMsg:
+		if msg.err != nil {
+			s.content.status.Set(msg.err.Error())
+			return s, func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+			}
+		}
+
+		s.checked = nil
+		s.content.details.Set("")
+		s.content.field.Raw().SetValue("")
+		s.content.status.Set("friend added")
+		return s, func() tea.Msg {
+			return router.LogMsg{Severity: router.SeveritySuccess, Text: "friend added"}
+		}
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			return s, func() tea.Msg {
+				return screen.BackMsg{}
+			}
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
+	_, cmd := s.content.list.Update(msg)
+	return s, cmd
+}
+
+func (s Screen) Help() string {
+	return s.content.list.Help()
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left, "add friend screen", "paste share link from your friend's profile", "")
+}
+
+func (s Screen) View() string {
+	s.content.field.Raw().Width = s.width - 10
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.header(),
+		s.content.list.View(),
+		"",
+		s.content.details.View(),
+		s.content.status.View(),
+	)
+}
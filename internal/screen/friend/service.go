@@ -0,0 +1,84 @@
+package friend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/friendly-social/cli/internal/share"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// errUnknownUser is returned by lookup when user can't be found among people with known access hashes.
+var errUnknownUser = errors.New("friend: user isn't in your feed, so their details can't be fetched without access hash")
+
+// Client is a subset of sdk.Client methods used by Service.
+type Client interface {
+	AddFriend(ctx context.Context, auth *sdk.Authorization, token sdk.FriendToken, userId sdk.UserId) error
+	GetUserDetails(ctx context.Context, auth *sdk.Authorization, userId sdk.UserId, accessHash sdk.UserAccessHash) (*sdk.UserDetails, error)
+	GetFeedQueue(ctx context.Context, auth *sdk.Authorization) (*sdk.FeedQueue, error)
+	GetNetworkDetails(ctx context.Context, auth *sdk.Authorization) (*sdk.NetworkDetails, error)
+}
+
+// Service provides logic of adding friends.
+type Service struct {
+	client Client
+}
+
+// NewService creates new Service from client.
+func NewService(client Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// parse unpacks friend token and user ID from entered share link. Error tells length of the link without whitespace,
+// so it's visible when only a part of the link was pasted.
+func parse(link string) (sdk.FriendToken, sdk.UserId, error) {
+	token, id, err := share.ParseLink(link)
+	if err != nil {
+		return sdk.FriendToken{}, sdk.UserId{}, fmt.Errorf("friend: failed to parse share link of %d characters: %w", len(share.Clean(link)), err)
+	}
+
+	return token, id, nil
+}
+
+// lookup finds details of user with provided ID. AddFriend doesn't need access hash, but GetUserDetails does,
+// so only users from the feed can be looked up. Returns errUnknownUser for anyone else.
+func (s *Service) lookup(ctx context.Context, user *sdk.Authorization, id sdk.UserId) (*sdk.UserDetails, error) {
+	network, err := s.client.GetNetworkDetails(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("friend: failed to get network details: %w", err)
+	}
+
+	if slices.ContainsFunc(network.Friends, func(friend sdk.UserDetails) bool { return friend.Id == id }) {
+		return nil, fmt.Errorf("friend: user %d is already your friend", id.Value())
+	}
+
+	feed, err := s.client.GetFeedQueue(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("friend: failed to get feed queue: %w", err)
+	}
+
+	index := slices.IndexFunc(feed.Entries, func(entry sdk.FeedEntry) bool { return entry.Details.Id == id })
+	if index < 0 {
+		return nil, errUnknownUser
+	}
+
+	details, err := s.client.GetUserDetails(ctx, user, id, feed.Entries[index].Details.AccessHash)
+	if err != nil {
+		return nil, fmt.Errorf("friend: failed to get user details: %w", err)
+	}
+
+	return details, nil
+}
+
+func (s *Service) add(user *sdk.Authorization, token sdk.FriendToken, id sdk.UserId) error {
+	err := s.client.AddFriend(context.Background(), user, token, id)
+	if err != nil {
+		return fmt.Errorf("friend: failed to add friend: %w", err)
+	}
+
+	return nil
+}
@@ -1,20 +1,32 @@
 package home
 
 import (
+	"slices"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/friendly-social/cli/internal/router"
 	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/auth"
 	"github.com/friendly-social/cli/internal/ui"
+	sdk "github.com/friendly-social/golang-sdk"
 )
 
 // Screen is a model of home screen.
 type Screen struct {
+	user *sdk.Authorization
+
 	content struct {
-		list *ui.List
+		list      *ui.List
+		shortcuts map[string]*ui.Button
 
 		buttons struct {
+			feed     *ui.Button
 			profile  *ui.Button
+			network  *ui.Button
+			friend   *ui.Button
+			requests *ui.Button
+			accounts *ui.Button
 			register *ui.Button
 			exit     *ui.Button
 		}
@@ -25,22 +37,63 @@ type Screen struct {
 func New() Screen {
 	result := Screen{}
 
-	result.content.buttons.register = ui.NewButton("Register", func() tea.Msg {
+	result.content.buttons.register = ui.NewButton("[r] Register", func() tea.Msg {
 		return screen.ChangeMsg{NewType: screen.TypeRegister}
 	})
-	result.content.buttons.profile = ui.NewButton("Profile", func() tea.Msg {
+	result.content.buttons.feed = ui.NewButton("[f] Feed", func() tea.Msg {
+		return screen.ChangeMsg{NewType: screen.TypeFeed}
+	})
+	result.content.buttons.profile = ui.NewButton("[p] Profile", func() tea.Msg {
 		return screen.ChangeMsg{NewType: screen.TypeProfile}
 	})
-	result.content.buttons.exit = ui.NewButton("Exit", tea.Quit)
-
-	result.content.list = ui.NewList(
-		result.content.buttons.profile,
-		result.content.buttons.register,
-		result.content.buttons.exit)
+	result.content.buttons.network = ui.NewButton("[n] Network", func() tea.Msg {
+		return screen.ChangeMsg{NewType: screen.TypeNetwork}
+	})
+	result.content.buttons.friend = ui.NewButton("[a] Add friend", func() tea.Msg {
+		return screen.ChangeMsg{NewType: screen.TypeFriend}
+	})
+	result.content.buttons.requests = ui.NewButton("[R] Friend requests", func() tea.Msg {
+		return screen.ChangeMsg{NewType: screen.TypeRequests}
+	})
+	result.content.buttons.accounts = ui.NewButton("[s] Switch account", func() tea.Msg {
+		return screen.ChangeMsg{NewType: screen.TypeAccounts}
+	})
+	result.content.buttons.exit = ui.NewButton("[q] Exit", tea.Quit)
+	result.content.shortcuts = map[string]*ui.Button{
+		"r": result.content.buttons.register,
+		"f": result.content.buttons.feed,
+		"p": result.content.buttons.profile,
+		"n": result.content.buttons.network,
+		"a": result.content.buttons.friend,
+		"R": result.content.buttons.requests,
+		"s": result.content.buttons.accounts,
+		"q": result.content.buttons.exit,
+	}
 
+	result.content.list = ui.NewList(result.menu(nil)...)
 	return result
 }
 
+// menu returns items of the menu available for user, which is nil until they log in.
+func (s Screen) menu(user *sdk.Authorization) []tea.Model {
+	if user == nil {
+		return []tea.Model{
+			s.content.buttons.register,
+			s.content.buttons.exit,
+		}
+	}
+
+	return []tea.Model{
+		s.content.buttons.feed,
+		s.content.buttons.profile,
+		s.content.buttons.network,
+		s.content.buttons.friend,
+		s.content.buttons.requests,
+		s.content.buttons.accounts,
+		s.content.buttons.exit,
+	}
+}
+
 func (Screen) ID() screen.Type {
 	return screen.TypeHome
 }
@@ -52,15 +105,52 @@ func (s Screen) Init() tea.Cmd {
 }
 
 func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case auth.LoginMsg:
+		s.user = msg.User
+		return s, s.content.list.Set(s.menu(msg.User)...)
+	case tea.KeyMsg:
+		button, ok := s.content.shortcuts[msg.String()]
+		if !ok {
+			break
+		}
+
+		index := slices.Index(s.menu(s.user), tea.Model(button))
+		if index < 0 {
+			return s, nil
+		}
+
+		return s, s.content.list.Activate(index)
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
 	_, cmd := s.content.list.Update(msg)
 	return s, cmd
 }
 
+func (Screen) Bindings() []ui.Binding {
+	return []ui.Binding{
+		{Key: "f", Action: "open feed"},
+		{Key: "p", Action: "open profile"},
+		{Key: "n", Action: "open network"},
+		{Key: "a", Action: "add friend"},
+		{Key: "R", Action: "open friend requests"},
+		{Key: "s", Action: "switch account"},
+		{Key: "r", Action: "register, before logging in"},
+		{Key: "q", Action: "exit"},
+	}
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left, "home screen", "")
+}
+
 func (s Screen) View() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
-		"home screen",
-		"",
+		s.header(),
 		s.content.list.View(),
 	)
 }
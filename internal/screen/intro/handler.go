@@ -0,0 +1,92 @@
+package intro
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/ui"
+)
+
+const text = `Welcome to Friendly!
+
+Friendly helps you meet people through friends you already have.
+You describe yourself with a nickname, a short description and
+comma separated interests, and your feed suggests people to befriend.
+
+The app is driven by modes, shown at the very bottom:
+  NORMAL  move with j/k (or arrows), press enter to use buttons
+  INSERT  press i on a field to type into it, esc to stop typing
+
+Esc in NORMAL mode goes back to the previous screen.
+
+Press ctrl+l at any time to see the log of what happened.`
+
+// Screen is a model of onboarding screen shown on the first run.
+type Screen struct {
+	content struct {
+		list *ui.List
+
+		button struct {
+			start *ui.Button
+			skip  *ui.Button
+		}
+	}
+}
+
+// New creates new Screen.
+func New() Screen {
+	result := Screen{}
+
+	result.content.button.start = ui.NewButton("Get started", func() tea.Msg {
+		return screen.ChangeMsg{NewType: screen.TypeRegister}
+	})
+	result.content.button.skip = ui.NewButton("Skip", func() tea.Msg {
+		return screen.ChangeMsg{NewType: screen.TypeHome}
+	})
+
+	result.content.list = ui.NewList(
+		result.content.button.start,
+		result.content.button.skip)
+
+	return result
+}
+
+func (Screen) ID() screen.Type {
+	return screen.TypeIntro
+}
+
+func (s Screen) Init() tea.Cmd {
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: ui.SelectMsg{}}
+	}
+}
+
+func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			return s, func() tea.Msg {
+				return screen.ChangeMsg{NewType: screen.TypeHome}
+			}
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
+	_, cmd := s.content.list.Update(msg)
+	return s, cmd
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left, text, "")
+}
+
+func (s Screen) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.header(),
+		s.content.list.View(),
+	)
+}
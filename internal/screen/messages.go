@@ -5,6 +5,9 @@ type ChangeMsg struct {
 	NewType Type
 }
 
+// BackMsg signals that router must return to the previous screen, or to home if there's none.
+type BackMsg struct{}
+
 // ErrorMsg is a message that represents an error occured in program.
 type ErrorMsg struct {
 	Value error
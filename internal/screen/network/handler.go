@@ -0,0 +1,216 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/ui"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// refreshMsg asks the Screen to reload the network.
+type refreshMsg struct{}
+
+// loadedMsg delivers freshly loaded friends to the Screen, or error if loading failed.
+type loadedMsg struct {
+	load    int
+	friends []sdk.UserDetails
+	err     error
+}
+
+// Screen is a model of network screen, which lists user's friends.
+type Screen struct {
+	service *Service
+	user    *sdk.Authorization
+	friends []sdk.UserDetails
+	loads   int
+	loading bool
+	err     error
+
+	// filter is the query friends are currently filtered by.
+	filter string
+
+	content struct {
+		list   *ui.List
+		status *ui.Label
+
+		field struct {
+			filter *ui.Field
+		}
+
+		button struct {
+			refresh *ui.Button
+			back    *ui.Button
+		}
+	}
+
+	width int
+}
+
+// New creates new Screen from Service.
+func New(service *Service) Screen {
+	result := Screen{
+		service: service,
+	}
+
+	filter := textinput.New()
+	filter.Placeholder = "Filter by nickname or interest"
+	filter.Prompt = "/ "
+	result.content.field.filter = ui.NewField(filter)
+
+	result.content.status = ui.NewLabel("log in to see your friends")
+	result.content.button.refresh = ui.NewButton("Refresh", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeNetwork, Inner: refreshMsg{}}
+	})
+	result.content.button.back = ui.NewButton("Back", func() tea.Msg {
+		return screen.BackMsg{}
+	})
+
+	result.content.list = ui.NewList(result.items()...)
+	return result
+}
+
+func (Screen) ID() screen.Type {
+	return screen.TypeNetwork
+}
+
+func (s Screen) Init() tea.Cmd {
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: ui.SelectMsg{}}
+	}
+}
+
+func (s Screen) load() (Screen, tea.Cmd) {
+	if s.user == nil {
+		return s, nil
+	}
+
+	s.loads++
+	load := s.loads
+	s.loading = true
+	s.content.status.Set(s.summary())
+
+	return s, func() tea.Msg {
+		friends, err := s.service.friends(context.Background(), s.user)
+		return router.TargetMsg{Type: s.ID(), Inner: loadedMsg{load: load, friends: friends, err: err}}
+	}
+}
+
+// items returns filter field, friends matching the filter and controls.
+func (s Screen) items() []tea.Model {
+	items := []tea.Model{s.content.field.filter}
+	for _, friend := range s.friends {
+		if !matches(friend, s.filter) {
+			continue
+		}
+
+		values := make([]string, 0, len(friend.Interests.Value()))
+		for _, interest := range friend.Interests.Value() {
+			values = append(values, interest.Value())
+		}
+
+		prefix := fmt.Sprintf("%s %s: ", ui.Avatar(friend.Nickname.Value(), friend.Avatar != nil), friend.Nickname.Value())
+		suffix := fmt.Sprintf(" [%s]", strings.Join(values, ", "))
+		items = append(items, ui.NewButton(
+			ui.Clamp(prefix, friend.Description.Value(), suffix, s.width-ui.ListIndent, ui.SummaryLines), nil))
+	}
+
+	return append(items, s.content.button.refresh, s.content.button.back)
+}
+
+func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		return s, s.content.list.Replace(s.items()...)
+	case auth.LoginMsg:
+		s.user = msg.User
+		return s.load()
+	case refreshMsg:
+		return s.load()
+	case loadedMsg:
+		if msg.load != s.loads {
+			return s, nil
+		}
+
+		s.loading = false
+		s.err = msg.err
+		if msg.err != nil {
+			s.content.status.Set(s.summary())
+			return s, func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+			}
+		}
+
+		s.friends = msg.friends
+		s.content.status.Set(s.summary())
+		return s, s.content.list.Set(s.items()...)
+	case tea.KeyMsg:
+		// keys typed into the filter aren't shortcuts
+		if s.content.field.filter.Raw().Focused() {
+			break
+		}
+
+		if s.err != nil && msg.String() == "r" {
+			return s.load()
+		}
+
+		if msg.String() == "esc" {
+			return s, func() tea.Msg {
+				return screen.BackMsg{}
+			}
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
+	_, cmd := s.content.list.Update(msg)
+	if s.content.field.filter.Value() == s.filter {
+		return s, cmd
+	}
+
+	// filter changed while typing, so friends are narrowed down keeping the filter field selected
+	s.filter = s.content.field.filter.Value()
+	return s, tea.Batch(cmd, s.content.list.Set(s.items()...))
+}
+
+// summary describes state of the network or number of friends in it.
+func (s Screen) summary() string {
+	if state, ok := ui.ListState(len(s.friends), s.err, s.loading, "no friends yet, add some with a share link"); !ok {
+		return state
+	}
+
+	return fmt.Sprintf("%d friends", len(s.friends))
+}
+
+func (s Screen) Debug() any {
+	return s.friends
+}
+
+func (Screen) Bindings() []ui.Binding {
+	return []ui.Binding{
+		{Key: "r", Action: "retry failed loading"},
+	}
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left, "network screen", ui.Wrap(s.content.status.View(), s.width), "")
+}
+
+func (s Screen) View() string {
+	s.content.field.filter.Raw().Width = s.width - 10
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.header(),
+		s.content.list.View(),
+	)
+}
@@ -0,0 +1,17 @@
+package network
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/friendly-social/cli/internal/demo"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/screen/screentest"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+func TestScreen_View(t *testing.T) {
+	driver := screentest.New(New(NewService(demo.NewClient())), nil)
+	driver.Send(tea.WindowSizeMsg{Width: 80, Height: 24}, auth.LoginMsg{User: &sdk.Authorization{}})
+	screentest.Golden(t, "loaded", driver.View())
+}
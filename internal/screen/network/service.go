@@ -0,0 +1,51 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// Client is a subset of sdk.Client methods used by Service.
+type Client interface {
+	GetNetworkDetails(ctx context.Context, auth *sdk.Authorization) (*sdk.NetworkDetails, error)
+}
+
+// Service provides logic of retrieving user's friends.
+type Service struct {
+	client Client
+}
+
+// NewService creates new Service from client.
+func NewService(client Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) friends(ctx context.Context, user *sdk.Authorization) ([]sdk.UserDetails, error) {
+	network, err := s.client.GetNetworkDetails(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("network: failed to get network details: %w", err)
+	}
+
+	return network.Friends, nil
+}
+
+// matches reports whether friend's nickname or one of interests contains query, ignoring case.
+func matches(friend sdk.UserDetails, query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if strings.Contains(strings.ToLower(friend.Nickname.Value()), query) {
+		return true
+	}
+
+	for _, interest := range friend.Interests.Value() {
+		if strings.Contains(strings.ToLower(interest.Value()), query) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,7 +1,10 @@
 package profile
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -9,19 +12,64 @@ import (
 	"github.com/friendly-social/cli/internal/router"
 	"github.com/friendly-social/cli/internal/screen"
 	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/screen/edit"
+	"github.com/friendly-social/cli/internal/screen/token"
 	"github.com/friendly-social/cli/internal/ui"
+	sdk "github.com/friendly-social/golang-sdk"
 )
 
+// refreshMsg asks the Screen to reload the profile.
+type refreshMsg struct{}
+
+// editMsg asks the Screen to open editing of loaded profile.
+type editMsg struct{}
+
+// exportMsg asks the Screen to export user's data.
+type exportMsg struct{}
+
+// loadedMsg delivers freshly loaded profile to the Screen. Network is nil if networkErr tells it failed to load.
+type loadedMsg struct {
+	load       int
+	details    *sdk.UserDetails
+	network    *sdk.NetworkDetails
+	networkErr error
+}
+
+// failedMsg signalizes that loading of the profile failed.
+type failedMsg struct {
+	load int
+	err  error
+}
+
+// cancelledMsg signalizes that loading of the profile was cancelled.
+type cancelledMsg struct {
+	load int
+}
+
 // Screen is a model of profile screen.
 type Screen struct {
 	service *Service
+	user    *sdk.Authorization
+	cancel  context.CancelFunc
+	loads   int
+	failed  bool
+
+	details *sdk.UserDetails
+	network *sdk.NetworkDetails
+	// avatar is computed once per load, since it doesn't change between renders
+	avatar string
 
 	content struct {
-		label *ui.Label
-		list  *ui.List
+		label  *ui.Label
+		status *ui.Label
+		list   *ui.List
 
 		button struct {
-			home *ui.Button
+			refresh *ui.Button
+			edit    *ui.Button
+			export  *ui.Button
+			share   *ui.Button
+			home    *ui.Button
 		}
 	}
 }
@@ -33,11 +81,28 @@ func New(service *Service) Screen {
 	}
 
 	result.content.label = ui.NewLabel("")
+	result.content.status = ui.NewLabel("")
+	result.content.button.refresh = ui.NewButton("Refresh", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeProfile, Inner: refreshMsg{}}
+	})
+	result.content.button.edit = ui.NewButton("Edit", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeProfile, Inner: editMsg{}}
+	})
+	result.content.button.export = ui.NewButton("Export", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeProfile, Inner: exportMsg{}}
+	})
+	result.content.button.share = ui.NewButton("Share link", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeToken, Inner: token.OpenMsg{}}
+	})
 	result.content.button.home = ui.NewButton("Back", func() tea.Msg {
-		return screen.ChangeMsg{NewType: screen.TypeHome}
+		return screen.BackMsg{}
 	})
 
 	result.content.list = ui.NewList(
+		result.content.button.refresh,
+		result.content.button.edit,
+		result.content.button.export,
+		result.content.button.share,
 		result.content.button.home)
 
 	return result
@@ -56,38 +121,184 @@ func (s Screen) Init() tea.Cmd {
 func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case auth.LoginMsg:
-		s.content.label.Set("loading...")
+		s.user = msg.User
+		return s.load()
+	case refreshMsg, edit.SavedMsg:
+		return s.load()
+	case editMsg:
+		if s.details == nil {
+			s.content.status.Set("wait until profile is loaded to edit it")
+			return s, nil
+		}
+
 		return s, func() tea.Msg {
-			self, err := s.service.get(msg.User)
-			if err != nil {
-				s.content.label.Set(fmt.Sprintf("error loading profile: %s", err.Error()))
-				return screen.TickMsg{}
-			}
+			return router.TargetMsg{Type: screen.TypeEdit, Inner: edit.OpenMsg{Details: s.details}}
+		}
+	case loadedMsg:
+		if msg.load != s.loads {
+			return s, nil
+		}
+
+		s.cancel = nil
+		s.failed = msg.networkErr != nil
+		s.details = msg.details
+		s.network = msg.network
+		s.avatar = s.service.avatar(msg.details)
 
-			var interests strings.Builder
-			interestsSlice := self.Interests.Value()
-			for i, interest := range interestsSlice {
-				interests.WriteString(interest.Value())
-				if i != len(interestsSlice)-1 {
-					interests.WriteString(", ")
-				}
+		var interests strings.Builder
+		interestsSlice := msg.details.Interests.Value()
+		for i, interest := range interestsSlice {
+			interests.WriteString(interest.Value())
+			if i != len(interestsSlice)-1 {
+				interests.WriteString(", ")
 			}
+		}
+
+		// failed network isn't shown as zero friends
+		status := router.StatusMsg{Nickname: msg.details.Nickname.Value(), FriendsFailed: msg.networkErr != nil}
+		var friends string
+		if msg.networkErr != nil {
+			friends = ui.ErrorStyle().Render(fmt.Sprintf("— (%s, r to retry)", msg.networkErr))
+		} else {
+			status.Friends = len(msg.network.Friends)
+			friends = strconv.Itoa(status.Friends)
+		}
+
+		s.content.label.Set(fmt.Sprintf(
+			"your logged in profile:\n%s\nnickname: %s\ndescription: %s\ninterests: %s\nsocial link: %s\navatar: %s\nfriends: %s",
+			ui.Avatar(msg.details.Nickname.Value(), msg.details.Avatar != nil),
+			msg.details.Nickname.Value(), msg.details.Description.Value(), interests.String(),
+			msg.details.SocialLink.Value(), s.avatar, friends))
+
+		cmds := []tea.Cmd{func() tea.Msg { return status }}
+		if msg.networkErr != nil {
+			cmds = append(cmds, func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityError, Text: msg.networkErr.Error()}
+			})
+		}
+
+		return s, tea.Batch(cmds...)
+	case failedMsg:
+		if msg.load != s.loads {
+			return s, nil
+		}
+
+		s.cancel = nil
+		s.failed = true
+		s.content.label.Set(ui.ErrorStyle().Render(fmt.Sprintf("error loading profile: %s (r to retry)", msg.err.Error())))
+		return s, func() tea.Msg {
+			return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+		}
+	case cancelledMsg:
+		if msg.load != s.loads {
+			return s, nil
+		}
 
-			s.content.label.Set(fmt.Sprintf(
-				"your logged in profile:\nnickname: %s\ndescription: %s\ninterests: %s\nsocial link: %s",
-				self.Nickname.Value(), self.Description.Value(), interests.String(), self.SocialLink.Value()))
+		s.cancel = nil
+		s.content.label.Set("loading cancelled")
+		return s, tea.Batch(
+			func() tea.Msg {
+				return screen.BackMsg{}
+			},
+			func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityInfo, Text: "profile loading cancelled"}
+			})
+	case tea.KeyMsg:
+		if s.cancel != nil && (msg.String() == "esc" || msg.String() == "ctrl+c") {
+			s.cancel()
+			return s, nil
+		}
+
+		if s.failed && msg.String() == "r" {
+			return s.load()
+		}
 
-			return screen.TickMsg{}
+		if msg.String() == "esc" {
+			return s, func() tea.Msg {
+				return screen.BackMsg{}
+			}
 		}
+	case exportMsg:
+		return s, s.export()
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
 	}
 
 	_, cmd := s.content.list.Update(msg)
 	return s, cmd
 }
 
+// load starts loading of the profile, cancelling the previous load if it's still running.
+// Results of superseded loads are ignored.
+func (s Screen) load() (Screen, tea.Cmd) {
+	if s.user == nil {
+		return s, nil
+	}
+
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.loads++
+	load := s.loads
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.content.label.Set("loading... (esc to cancel)")
+
+	return s, func() tea.Msg {
+		details, network, err := s.service.load(ctx, s.user)
+		if errors.Is(err, context.Canceled) {
+			return router.TargetMsg{Type: s.ID(), Inner: cancelledMsg{load: load}}
+		}
+
+		if details == nil {
+			return router.TargetMsg{Type: s.ID(), Inner: failedMsg{load: load, err: err}}
+		}
+
+		return router.TargetMsg{Type: s.ID(), Inner: loadedMsg{load: load, details: details, network: network, networkErr: err}}
+	}
+}
+
+func (s Screen) export() tea.Cmd {
+	if s.user == nil {
+		s.content.status.Set("log in to export your data")
+		return nil
+	}
+
+	s.content.status.Set("exporting...")
+	return func() tea.Msg {
+		err := s.service.export(context.Background(), s.user)
+		if err != nil {
+			s.content.status.Set(err.Error())
+			return router.LogMsg{Severity: router.SeverityError, Text: err.Error()}
+		}
+
+		s.content.status.Set(fmt.Sprintf("exported to %s", s.service.exportPath))
+		return router.LogMsg{Severity: router.SeveritySuccess, Text: s.content.status.Value()}
+	}
+}
+
+func (s Screen) Debug() any {
+	return document{Profile: s.details, Network: s.network}
+}
+
+func (Screen) Bindings() []ui.Binding {
+	return []ui.Binding{
+		{Key: "r", Action: "retry failed loading"},
+		{Key: "esc", Action: "cancel loading"},
+	}
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left, s.content.label.View(), "")
+}
+
 func (s Screen) View() string {
 	return lipgloss.JoinVertical(lipgloss.Left,
-		s.content.label.View(),
+		s.header(),
+		s.content.list.View(),
 		"",
-		s.content.list.View())
+		s.content.status.View())
 }
@@ -0,0 +1,37 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/friendly-social/cli/internal/demo"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/screen/screentest"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// brokenNetwork fails to load friends, while the rest of the profile loads.
+type brokenNetwork struct {
+	*demo.Client
+}
+
+func (brokenNetwork) GetNetworkDetails(context.Context, *sdk.Authorization) (*sdk.NetworkDetails, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestScreen_View(t *testing.T) {
+	tests := map[string]Client{
+		"loaded":         demo.NewClient(),
+		"broken_network": brokenNetwork{demo.NewClient()},
+	}
+
+	for name, client := range tests {
+		t.Run(name, func(t *testing.T) {
+			driver := screentest.New(New(NewService(client)), nil)
+			driver.Send(tea.WindowSizeMsg{Width: 80, Height: 24}, auth.LoginMsg{User: &sdk.Authorization{}})
+			screentest.Golden(t, name, driver.View())
+		})
+	}
+}
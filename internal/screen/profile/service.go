@@ -2,27 +2,160 @@ package profile
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
 
 	sdk "github.com/friendly-social/golang-sdk"
 )
 
+// Client is a subset of sdk.Client methods used by Service.
+type Client interface {
+	GetSelfDetails(ctx context.Context, auth *sdk.Authorization) (*sdk.UserDetails, error)
+	GetNetworkDetails(ctx context.Context, auth *sdk.Authorization) (*sdk.NetworkDetails, error)
+	GetFileURL(fd *sdk.FileDescriptor) (string, error)
+}
+
+// document combines user's data written by Service.export.
+type document struct {
+	Profile *sdk.UserDetails    `json:"profile"`
+	Network *sdk.NetworkDetails `json:"network"`
+}
+
+const defaultExportPath = "friendly-export.json"
+
 // Service provides logic of retrieving profile data.
 type Service struct {
-	client *sdk.Client
+	client     Client
+	exportPath string
 }
 
 // NewService creates new Service from client.
-func NewService(client *sdk.Client) *Service {
+func NewService(client Client) *Service {
 	return &Service{
-		client: client,
+		client:     client,
+		exportPath: defaultExportPath,
 	}
 }
 
-func (s *Service) get(user *sdk.Authorization) (*sdk.UserDetails, error) {
-	details, err := s.client.GetSelfDetails(context.Background(), user)
+// WithFolder keeps export file in dir, unless WithExportPath sets another path.
+func (s *Service) WithFolder(dir string) *Service {
+	if dir != "" {
+		s.exportPath = filepath.Join(dir, defaultExportPath)
+	}
+
+	return s
+}
+
+// WithExportPath sets path of the file user's data is exported to. Empty path stands for the default one.
+func (s *Service) WithExportPath(path string) *Service {
+	if path != "" {
+		s.exportPath = path
+	}
+
+	return s
+}
+
+func (s *Service) get(ctx context.Context, user *sdk.Authorization) (*sdk.UserDetails, error) {
+	details, err := s.client.GetSelfDetails(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
 	return details, nil
 }
+
+func (s *Service) network(ctx context.Context, user *sdk.Authorization) (*sdk.NetworkDetails, error) {
+	network, err := s.client.GetNetworkDetails(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return network, nil
+}
+
+// avatar describes where avatar of user with details can be opened.
+func (s *Service) avatar(details *sdk.UserDetails) string {
+	if details.Avatar == nil {
+		return "none"
+	}
+
+	url, err := s.client.GetFileURL(details.Avatar)
+	if err != nil {
+		return fmt.Sprintf("unavailable (%s)", err)
+	}
+
+	return url
+}
+
+// load fetches user's details and network concurrently, reporting errors of both requests.
+// Details are returned even if only the network failed, so profile can be shown without friends.
+func (s *Service) load(ctx context.Context, user *sdk.Authorization) (*sdk.UserDetails, *sdk.NetworkDetails, error) {
+	var (
+		wg         sync.WaitGroup
+		details    *sdk.UserDetails
+		network    *sdk.NetworkDetails
+		detailsErr error
+		networkErr error
+	)
+
+	wg.Go(func() {
+		details, detailsErr = s.get(ctx, user)
+		if detailsErr != nil {
+			detailsErr = fmt.Errorf("failed to load details: %w", detailsErr)
+		}
+	})
+	wg.Go(func() {
+		network, networkErr = s.network(ctx, user)
+		if networkErr != nil {
+			networkErr = fmt.Errorf("failed to load network: %w", networkErr)
+		}
+	})
+	wg.Wait()
+
+	err := errors.Join(detailsErr, networkErr)
+	if detailsErr != nil {
+		return nil, nil, err
+	}
+
+	return details, network, err
+}
+
+// export writes user's details and network to the export file as JSON. Existing file is never overwritten.
+func (s *Service) export(ctx context.Context, user *sdk.Authorization) error {
+	details, network, err := s.load(ctx, user)
+	if err != nil {
+		return fmt.Errorf("profile: failed to export: %w", err)
+	}
+
+	bytes, err := json.MarshalIndent(document{Profile: details, Network: network}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("profile: failed to marshal export: %w", err)
+	}
+
+	file, err := os.OpenFile(s.exportPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("profile: %s already exists, remove it or choose another --export path", s.exportPath)
+	}
+
+	if err != nil {
+		return fmt.Errorf("profile: failed to create export file: %w", err)
+	}
+
+	_, err = file.Write(bytes)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		// partial file would make every retry fail with already exists
+		os.Remove(s.exportPath) //nolint:errcheck
+		return fmt.Errorf("profile: failed to write export: %w", err)
+	}
+
+	return nil
+}
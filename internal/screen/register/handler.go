@@ -1,18 +1,29 @@
 package register
 
 import (
+	"context"
+	"errors"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/interests"
 	"github.com/friendly-social/cli/internal/router"
 	"github.com/friendly-social/cli/internal/screen"
 	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/secret"
 	"github.com/friendly-social/cli/internal/ui"
 )
 
+// submitMsg asks the Screen to register with entered values.
+type submitMsg struct{}
+
 // Screen is a model of registration screen.
 type Screen struct {
 	service *Service
+	// cancel stops registration in progress, it's nil if there's none
+	cancel context.CancelFunc
 
 	content struct {
 		list   *ui.List
@@ -22,8 +33,9 @@ type Screen struct {
 		field  struct {
 			nickname    *ui.Field
 			description *ui.Field
-			interests   *ui.Field
+			interests   *ui.Tags
 			social      *ui.Field
+			avatar      *ui.Field
 		}
 
 		buttons []*ui.Button
@@ -51,35 +63,30 @@ func New(service *Service) Screen {
 		service: service,
 	}
 
-	result.content.field.nickname = field("Nickname", 256)
-	result.content.field.description = field("Description", 1024)
-	result.content.field.interests = field("Interests", 0)
-	result.content.field.social = field("Social Link", 1024)
-
-	result.content.button.submit = ui.NewButton("Submit",
-		func() tea.Msg {
-			result.content.status.Set("authenticating...")
-			user, err := service.register(
-				result.content.field.nickname.Value(),
-				result.content.field.description.Value(),
-				result.content.field.interests.Value(),
-				result.content.field.social.Value())
-
-			if err != nil {
-				return screen.ErrorMsg{Value: err}
-			}
+	result.content.field.nickname = field("Nickname", 256).WithCounter()
+	result.content.field.description = field("Description", 1024).WithCounter()
+	input := textinput.New()
+	input.Placeholder = "Interests"
+	input.Prompt = ""
+	result.content.field.interests = ui.NewTags(input, func(text string) ([]string, []error) {
+		parsed, errs := interests.Parse(text)
+		return interests.Values(parsed), errs
+	})
+	result.content.field.social = field("Social Link", 1024).WithCounter()
+	result.content.field.avatar = field("Avatar (optional path to an image)", 0)
 
-			return router.BroadcastMsg{Inner: auth.LoginMsg{User: user}}
-		})
+	result.content.button.submit = ui.NewButton("Submit", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeRegister, Inner: submitMsg{}}
+	})
 	result.content.button.back = ui.NewButton("Back", func() tea.Msg {
-		return screen.ChangeMsg{NewType: screen.TypeHome}
+		return screen.BackMsg{}
 	})
 
 	result.content.fields = []*ui.Field{
 		result.content.field.nickname,
 		result.content.field.description,
-		result.content.field.interests,
 		result.content.field.social,
+		result.content.field.avatar,
 	}
 
 	result.content.buttons = []*ui.Button{
@@ -93,6 +100,7 @@ func New(service *Service) Screen {
 		result.content.field.description,
 		result.content.field.interests,
 		result.content.field.social,
+		result.content.field.avatar,
 		result.content.button.submit,
 		result.content.button.back)
 
@@ -111,8 +119,9 @@ func (s Screen) Init() tea.Cmd {
 				return screen.ErrorMsg{Value: err}
 			}
 
+			// no saved credentials means it's the first run
 			if user == nil {
-				return nil
+				return screen.ChangeMsg{NewType: screen.TypeIntro}
 			}
 
 			return router.BroadcastMsg{Inner: auth.LoginMsg{User: user}}
@@ -122,35 +131,112 @@ func (s Screen) Init() tea.Cmd {
 		})
 }
 
+// submit registers with entered values until it finishes or esc cancels it.
+func (s Screen) submit() (Screen, tea.Cmd) {
+	if s.cancel != nil {
+		return s, nil
+	}
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.content.status.Set("authenticating... (esc to cancel)")
+
+	nickname, description := s.content.field.nickname.Value(), s.content.field.description.Value()
+	interests, social := s.content.field.interests.Value(), s.content.field.social.Value()
+	avatar := strings.TrimSpace(s.content.field.avatar.Value())
+	return s, func() tea.Msg {
+		user, err := s.service.register(ctx, nickname, description, interests, social, avatar)
+		if err != nil {
+			return screen.ErrorMsg{Value: err}
+		}
+
+		return router.BroadcastMsg{Inner: auth.LoginMsg{User: user}}
+	}
+}
+
 func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		s.width = msg.Width
 		s.height = msg.Height
+	case submitMsg:
+		return s.submit()
 	case auth.LoginMsg:
-		return s, func() tea.Msg {
-			return screen.ChangeMsg{NewType: screen.TypeHome}
+		if s.cancel != nil {
+			s.cancel()
+			s.cancel = nil
+			s.content.status.Set("")
 		}
+
+		return s, tea.Batch(
+			func() tea.Msg {
+				return screen.ChangeMsg{NewType: screen.TypeHome}
+			},
+			func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityInfo, Text: "logged in as " + secret.Authorization(msg.User)}
+			})
 	case screen.ErrorMsg:
+		if s.cancel != nil {
+			s.cancel()
+			s.cancel = nil
+		}
+
+		if errors.Is(msg.Value, context.Canceled) {
+			s.content.status.Set("registration cancelled")
+			return s, nil
+		}
+
 		s.content.status.Set(msg.Value.Error())
 		return s, nil
+	case tea.KeyMsg:
+		if s.cancel != nil && msg.String() == "esc" {
+			s.cancel()
+			return s, nil
+		}
+
+		if msg.String() == "esc" {
+			return s, func() tea.Msg {
+				return screen.BackMsg{}
+			}
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
 	}
 
 	_, cmd := s.content.list.Update(msg)
 	return s, cmd
 }
 
+func (s Screen) Help() string {
+	return s.content.list.Help()
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left, "registration screen", "")
+}
+
 func (s Screen) View() string {
 	for _, field := range s.content.fields {
 		field.Raw().Width = s.width - 10
 	}
+	s.content.field.interests.Raw().Width = s.width - 10
+
+	hints := check(
+		s.content.field.nickname.Value(),
+		s.content.field.description.Value(),
+		s.content.field.interests.Value(),
+		s.content.field.social.Value())
+	for i, hint := range hints {
+		hints[i] = ui.ErrorStyle().Render(hint)
+	}
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
-		"registration screen",
-		"",
+		s.header(),
 		s.content.list.View(),
 		"",
+		lipgloss.JoinVertical(lipgloss.Left, hints...),
 		s.content.status.View(),
 	)
 }
@@ -3,114 +3,554 @@ package register
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/friendly-social/cli/internal/dirs"
 	sdk "github.com/friendly-social/golang-sdk"
 )
 
+// Environment variables holding credentials, which take precedence over the saved ones.
 const (
-	saveFile   = "user.json"
-	saveFolder = "friendly"
+	userIdEnv     = "FRIENDLY_USER_ID"
+	tokenEnv      = "FRIENDLY_TOKEN"
+	accessHashEnv = "FRIENDLY_ACCESS_HASH"
 )
 
+// maxAvatarSize limits size of uploaded avatar, larger files are rejected before uploading.
+const maxAvatarSize = 5 << 20
+
+const (
+	saveFile = "user.json"
+	// profilesFile holds credentials of named profiles as JSON object keyed by profile name.
+	profilesFile = "profiles.json"
+	// legacyFolder is a folder in user cache dir, where credentials were saved before data dir was used.
+	legacyFolder = "friendly"
+)
+
+// Client is a subset of sdk.Client methods used by Service.
+type Client interface {
+	Register(ctx context.Context, nickname sdk.Nickname, description sdk.UserDescription, interests sdk.Interests, avatar *sdk.FileDescriptor, link sdk.SocialLink) (*sdk.Authorization, error)
+	UploadFile(ctx context.Context, filename string, reader io.Reader) (*sdk.FileDescriptor, error)
+}
+
 // Service provides registration logic.
 type Service struct {
-	client *sdk.Client
+	client Client
+	folder string
+
+	mu      sync.Mutex
+	user    *sdk.Authorization
+	profile string
 }
 
-// NewService creates Service from sdk.Client.
-func NewService(client *sdk.Client) *Service {
+// NewService creates Service from Client.
+func NewService(client Client) *Service {
 	return &Service{
 		client: client,
 	}
 }
 
-func (s *Service) load() (*sdk.Authorization, error) {
+// WithFolder sets custom folder for saving user's credentials. Empty folder stands for the default data dir.
+func (s *Service) WithFolder(folder string) *Service {
+	s.folder = folder
+	return s
+}
+
+// WithProfile sets name of the profile credentials are kept under. Empty name stands for the default profile,
+// which is kept in its own save file as before profiles existed.
+func (s *Service) WithProfile(name string) *Service {
+	s.profile = name
+	return s
+}
+
+func (s *Service) dir() (string, error) {
+	if s.folder != "" {
+		return s.folder, nil
+	}
+
+	resolved, err := dirs.Resolve("")
+	if err != nil {
+		return "", fmt.Errorf("register: failed to resolve data dir: %w", err)
+	}
+
+	return resolved.Data, nil
+}
+
+// path returns path of the save file, falling back to the legacy one in user cache dir
+// if credentials haven't been saved to the default data dir yet. Persist then moves them to the data dir.
+func (s *Service) path() (string, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, saveFile)
+	if _, err := os.Stat(path); s.folder != "" || !os.IsNotExist(err) {
+		return path, nil
+	}
+
 	cacheDir, err := os.UserCacheDir()
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to get user cache dir: %w", err)
+		return path, nil
 	}
 
-	saveFile := filepath.Join(cacheDir, saveFolder, saveFile)
-	_, err = os.Stat(saveFile)
-	if os.IsNotExist(err) {
+	legacy := filepath.Join(cacheDir, legacyFolder, saveFile)
+	if _, err := os.Stat(legacy); err != nil {
+		return path, nil
+	}
+
+	return legacy, nil
+}
+
+// User returns credentials of the user from environment or save file, or nil if they haven't registered yet.
+func (s *Service) User() (*sdk.Authorization, error) {
+	return s.load()
+}
+
+// Persist saves credentials of the current session, if there are any. It's meant to be called on exit.
+func (s *Service) Persist() error {
+	s.mu.Lock()
+	user, profile := s.user, s.profile
+	s.mu.Unlock()
+
+	if user == nil {
+		return nil
+	}
+
+	return s.save(profile, user)
+}
+
+// Profile returns name of the current profile.
+func (s *Service) Profile() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.profile
+}
+
+// Profiles returns names of profiles with saved credentials, the default one first as an empty name.
+func (s *Service) Profiles() ([]string, error) {
+	names := make([]string, 0)
+	path, err := s.path()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		names = append(names, "")
+	}
+
+	profiles, err := s.profiles()
+	if err != nil {
+		return nil, err
+	}
+
+	named := make([]string, 0, len(profiles))
+	for name := range profiles {
+		named = append(named, name)
+	}
+
+	slices.Sort(named)
+	return append(names, named...), nil
+}
+
+// Switch makes profile with name the current session, returning its saved credentials.
+func (s *Service) Switch(name string) (*sdk.Authorization, error) {
+	user, err := s.saved(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("register: profile %q has no saved credentials", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.user, s.profile = user, name
+	return user, nil
+}
+
+// remember makes user the current session, which is saved by Persist.
+func (s *Service) remember(user *sdk.Authorization) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.user = user
+}
+
+// save writes user to the save file of profile, keeping credentials of other named profiles.
+func (s *Service) save(profile string, user *sdk.Authorization) error {
+	if profile == "" {
+		userBytes, err := json.Marshal(user)
+		if err != nil {
+			return fmt.Errorf("register: failed to marshal user data: %w", err)
+		}
+
+		return s.write(saveFile, userBytes)
+	}
+
+	profiles, err := s.profiles()
+	if err != nil {
+		return err
+	}
+
+	profiles[profile] = user
+	profilesBytes, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return fmt.Errorf("register: failed to marshal profiles: %w", err)
+	}
+
+	return s.write(profilesFile, profilesBytes)
+}
+
+// write writes userBytes to a temporary file and renames it over the save file with name,
+// so interrupted write never leaves corrupted credentials behind.
+func (s *Service) write(name string, userBytes []byte) error {
+	dir, err := s.dir()
+	if err != nil {
+		return err
+	}
+
+	err = dirs.Ensure(dir)
+	if err != nil {
+		return fmt.Errorf("register: failed to create save folder: %w", err)
+	}
+
+	file, err := os.CreateTemp(dir, name+".*")
+	if err != nil {
+		return fmt.Errorf("register: failed to create temporary save file: %w", err)
+	}
+	defer os.Remove(file.Name()) //nolint:errcheck
+
+	_, err = file.Write(userBytes)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("register: failed to write user data to temporary save file: %w", err)
+	}
+
+	err = os.Rename(file.Name(), filepath.Join(dir, name))
+	if err != nil {
+		return fmt.Errorf("register: failed to replace save file: %w", err)
+	}
+
+	return nil
+}
+
+// fromEnv reads credentials from environment variables. Returns nil if none of them is set.
+func fromEnv() (*sdk.Authorization, error) {
+	values := map[string]string{
+		userIdEnv:     os.Getenv(userIdEnv),
+		tokenEnv:      os.Getenv(tokenEnv),
+		accessHashEnv: os.Getenv(accessHashEnv),
+	}
+
+	missing := make([]string, 0, len(values))
+	for _, name := range []string{userIdEnv, tokenEnv, accessHashEnv} {
+		if values[name] == "" {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) == len(values) {
 		return nil, nil
 	}
 
-	userBytes, err := os.ReadFile(saveFile)
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("register: credentials in environment are incomplete, set %s too", strings.Join(missing, ", "))
+	}
+
+	id, err := strconv.ParseInt(values[userIdEnv], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("register: %s must be a number: %w", userIdEnv, err)
+	}
+
+	token, err := sdk.NewToken(values[tokenEnv])
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to read user bytes: %w", err)
+		return nil, fmt.Errorf("register: %s is invalid: %w", tokenEnv, err)
 	}
 
-	user := new(sdk.Authorization)
-	err = json.Unmarshal(userBytes, user)
+	accessHash, err := sdk.NewUserAccessHash(values[accessHashEnv])
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to unmarshal user bytes: %w", err)
+		return nil, fmt.Errorf("register: %s is invalid: %w", accessHashEnv, err)
 	}
 
+	return &sdk.Authorization{Id: sdk.NewUserId(id), Token: token, AccessHash: accessHash}, nil
+}
+
+// load returns credentials from environment or saved ones, in that order of precedence.
+// Credentials from environment are never remembered, so Persist doesn't write them to disk.
+func (s *Service) load() (*sdk.Authorization, error) {
+	user, err := fromEnv()
+	if user != nil || err != nil {
+		return user, err
+	}
+
+	user, err = s.saved(s.Profile())
+	if user == nil || err != nil {
+		return nil, err
+	}
+
+	s.remember(user)
 	return user, nil
 }
 
-func (s *Service) register(nicknameString, descriptionString, interestsString, socialString string) (*sdk.Authorization, error) {
-	nickname, err := sdk.NewNickname(nicknameString)
+// saved returns credentials saved under profile, or nil if there are none.
+func (s *Service) saved(profile string) (*sdk.Authorization, error) {
+	var user *sdk.Authorization
+	if profile == "" {
+		path, err := s.path()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = os.Stat(path)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		userBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("register: failed to read user bytes: %w", err)
+		}
+
+		user = new(sdk.Authorization)
+		err = json.Unmarshal(userBytes, user)
+		if err != nil {
+			return nil, fmt.Errorf("register: failed to unmarshal user bytes: %w", err)
+		}
+	} else {
+		profiles, err := s.profiles()
+		if err != nil {
+			return nil, err
+		}
+
+		user = profiles[profile]
+		if user == nil {
+			return nil, nil
+		}
+	}
+
+	err := validate(user)
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to create nickname: %w", err)
+		return nil, fmt.Errorf("register: cached user is corrupted: %w", err)
 	}
 
-	description, err := sdk.NewUserDescription(descriptionString)
+	return user, nil
+}
+
+// profiles reads credentials of all named profiles. Missing profiles file means there are none.
+func (s *Service) profiles() (map[string]*sdk.Authorization, error) {
+	dir, err := s.dir()
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to create description: %w", err)
+		return nil, err
 	}
 
-	interestsSlice := make([]sdk.Interest, 0)
-	for interestStr := range strings.SplitSeq(interestsString, ",") {
-		interest, err := sdk.NewInterest(strings.TrimSpace(interestStr))
+	profiles := make(map[string]*sdk.Authorization)
+	profilesBytes, err := os.ReadFile(filepath.Join(dir, profilesFile))
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("register: failed to read profiles: %w", err)
+	}
+
+	err = json.Unmarshal(profilesBytes, &profiles)
+	if err != nil {
+		return nil, fmt.Errorf("register: failed to unmarshal profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// validate checks that user's AccessHash and Token pass the same validation as freshly created ones.
+func validate(user *sdk.Authorization) error {
+	_, err := sdk.NewUserAccessHash(user.AccessHash.Value())
+	if err != nil {
+		return err
+	}
+
+	_, err = sdk.NewToken(user.Token.Value())
+	return err
+}
+
+// parseInterests creates Interests from values entered as tags, reporting every invalid value.
+func parseInterests(values []string) (sdk.Interests, error) {
+	interestsSlice := make([]sdk.Interest, 0, len(values))
+	errs := make([]error, 0)
+	for _, value := range values {
+		interest, err := sdk.NewInterest(strings.TrimSpace(value))
 		if err != nil {
-			return nil, fmt.Errorf("register: failed to create interest: %w", err)
+			errs = append(errs, fmt.Errorf("register: failed to create interest %q: %w", value, err))
+			continue
 		}
 
 		interestsSlice = append(interestsSlice, interest)
 	}
 
+	if len(errs) != 0 {
+		return sdk.Interests{}, errors.Join(errs...)
+	}
+
 	interests, err := sdk.NewInterests(interestsSlice...)
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to create interests: %w", err)
+		return sdk.Interests{}, fmt.Errorf("register: failed to create interests: %w", err)
 	}
 
-	socialLink, err := sdk.NewSocialLink(socialString)
+	return interests, nil
+}
+
+// split returns errors joined by errors.Join, or err itself if it isn't joined.
+func split(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+
+	return []error{err}
+}
+
+// check validates entered values the same way register does, skipping fields which aren't filled yet.
+func check(nicknameString, descriptionString string, interestsSlice []string, socialString string) []string {
+	hints := make([]string, 0)
+	if nicknameString != "" {
+		if _, err := sdk.NewNickname(nicknameString); err != nil {
+			hints = append(hints, "nickname: "+err.Error())
+		}
+	}
+
+	if descriptionString != "" {
+		if _, err := sdk.NewUserDescription(descriptionString); err != nil {
+			hints = append(hints, "description: "+err.Error())
+		}
+	}
+
+	if len(interestsSlice) != 0 {
+		if _, err := parseInterests(interestsSlice); err != nil {
+			for _, err := range split(err) {
+				hints = append(hints, "interests: "+errors.Unwrap(err).Error())
+			}
+		}
+	}
+
+	if socialString != "" {
+		if _, err := sdk.NewSocialLink(socialString); err != nil {
+			hints = append(hints, "social link: "+err.Error())
+		}
+	}
+
+	return hints
+}
+
+// upload sends image at path to the server to be used as avatar. Files which don't look like images are rejected
+// before uploading, since the server stores whatever it receives.
+func (s *Service) upload(ctx context.Context, path string) (*sdk.FileDescriptor, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to create social link: %w", err)
+		return nil, fmt.Errorf("register: failed to open avatar: %w", err)
 	}
+	defer file.Close() //nolint:errcheck
 
-	user, err := s.client.Register(context.Background(), nickname, description, interests, nil, socialLink)
+	info, err := file.Stat()
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to register: %w", err)
+		return nil, fmt.Errorf("register: failed to read avatar: %w", err)
+	}
+
+	if info.Size() > maxAvatarSize {
+		return nil, fmt.Errorf("register: avatar must be at most %d MB, %s is %.1f MB",
+			maxAvatarSize>>20, filepath.Base(path), float64(info.Size())/(1<<20))
 	}
 
-	cacheFolder, err := os.UserCacheDir()
+	// DetectContentType considers at most 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("register: failed to read avatar: %w", err)
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("register: avatar must be an image, %s is %s", filepath.Base(path), contentType)
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to get user cache directory: %w", err)
+		return nil, fmt.Errorf("register: failed to read avatar: %w", err)
 	}
 
-	saveFolder := filepath.Join(cacheFolder, saveFolder)
-	err = os.MkdirAll(saveFolder, 0700)
+	avatar, err := s.client.UploadFile(ctx, filepath.Base(path), file)
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to create save folder: %w", err)
+		return nil, fmt.Errorf("register: failed to upload avatar: %w", err)
 	}
 
-	userBytes, err := json.Marshal(user)
+	return avatar, nil
+}
+
+// register creates an account, uploading avatar from avatarPath first unless it's empty.
+// Every invalid field is reported at once, joined into one error line per field or interest.
+// Upload isn't limited by --timeout, so cancelling ctx is the only way to stop it on a stalled connection.
+func (s *Service) register(ctx context.Context, nicknameString, descriptionString string, interestsSlice []string, socialString, avatarPath string) (*sdk.Authorization, error) {
+	errs := make([]error, 0)
+	nickname, err := sdk.NewNickname(nicknameString)
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to marshal user data: %w", err)
+		errs = append(errs, fmt.Errorf("register: failed to create nickname: %w", err))
+	}
+
+	description, err := sdk.NewUserDescription(descriptionString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("register: failed to create description: %w", err))
+	}
+
+	interests, err := parseInterests(interestsSlice)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	socialLink, err := sdk.NewSocialLink(socialString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("register: failed to create social link: %w", err))
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// avatar is uploaded only once the rest is valid, since uploaded files can't be deleted
+	var avatar *sdk.FileDescriptor
+	if avatarPath != "" {
+		avatar, err = s.upload(ctx, avatarPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.client.Register(ctx, nickname, description, interests, avatar, socialLink)
+	if err != nil {
+		return nil, fmt.Errorf("register: failed to register: %w", err)
 	}
 
-	saveFile := filepath.Join(saveFolder, saveFile)
-	err = os.WriteFile(saveFile, userBytes, 0600)
+	err = s.save(s.Profile(), user)
 	if err != nil {
-		return nil, fmt.Errorf("register: failed to write user data to save file: %w", err)
+		return nil, err
 	}
 
+	s.remember(user)
 	return user, nil
 }
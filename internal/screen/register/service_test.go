@@ -0,0 +1,273 @@
+package register
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+func writeUser(t *testing.T, dir string, user any) {
+	t.Helper()
+
+	bytes, err := json.Marshal(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, saveFile), bytes, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	accessHash, _ := sdk.NewUserAccessHash(strings.Repeat("a", 256))
+	token, _ := sdk.NewToken(strings.Repeat("b", 256))
+	user := &sdk.Authorization{Id: sdk.NewUserId(1), AccessHash: accessHash, Token: token}
+	writeUser(t, dir, user)
+
+	loaded, err := NewService(nil).WithFolder(dir).load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *loaded != *user {
+		t.Fatalf("expected %v, got %v", user, loaded)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	loaded, err := NewService(nil).WithFolder(t.TempDir()).load()
+	if err != nil || loaded != nil {
+		t.Fatalf("expected no user and no error, got %v, %v", loaded, err)
+	}
+}
+
+func TestLoad_ShortFields(t *testing.T) {
+	tests := map[string]struct {
+		user map[string]any
+		err  error
+	}{
+		"short access hash": {
+			user: map[string]any{"id": 1, "accessHash": "short", "token": strings.Repeat("b", 256)},
+			err:  sdk.ErrUserAccessHashLengthMustBe256,
+		},
+		"short token": {
+			user: map[string]any{"id": 1, "accessHash": strings.Repeat("a", 256), "token": "short"},
+			err:  sdk.ErrTokenLengthMustBe256,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeUser(t, dir, test.user)
+
+			_, err := NewService(nil).WithFolder(dir).load()
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestPersist(t *testing.T) {
+	dir := t.TempDir()
+	accessHash, _ := sdk.NewUserAccessHash(strings.Repeat("a", 256))
+	token, _ := sdk.NewToken(strings.Repeat("b", 256))
+	user := &sdk.Authorization{Id: sdk.NewUserId(1), AccessHash: accessHash, Token: token}
+
+	service := NewService(nil).WithFolder(dir)
+	service.remember(user)
+	if err := service.Persist(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// temporary file must be renamed over the save file, not left next to it
+	if len(entries) != 1 || entries[0].Name() != saveFile {
+		t.Fatalf("expected only %s in save folder, got %v", saveFile, entries)
+	}
+
+	loaded, err := NewService(nil).WithFolder(dir).load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *loaded != *user {
+		t.Fatalf("expected %v, got %v", user, loaded)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	dir := t.TempDir()
+	accessHash, _ := sdk.NewUserAccessHash(strings.Repeat("a", 256))
+	token, _ := sdk.NewToken(strings.Repeat("b", 256))
+	users := map[string]*sdk.Authorization{
+		"work":     {Id: sdk.NewUserId(1), AccessHash: accessHash, Token: token},
+		"personal": {Id: sdk.NewUserId(2), AccessHash: accessHash, Token: token},
+	}
+
+	for name, user := range users {
+		service := NewService(nil).WithFolder(dir).WithProfile(name)
+		service.remember(user)
+		if err := service.Persist(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := NewService(nil).WithFolder(dir).WithProfile("work")
+	loaded, err := service.load()
+	if err != nil || *loaded != *users["work"] {
+		t.Fatalf("expected %v, got %v, %v", users["work"], loaded, err)
+	}
+
+	// default profile isn't registered, so only named ones are listed
+	names, err := service.Profiles()
+	if err != nil || !slices.Equal(names, []string{"personal", "work"}) {
+		t.Fatalf("expected personal and work profiles, got %v, %v", names, err)
+	}
+
+	switched, err := service.Switch("personal")
+	if err != nil || *switched != *users["personal"] || service.Profile() != "personal" {
+		t.Fatalf("expected to switch to %v, got %v, %v", users["personal"], switched, err)
+	}
+
+	if _, err := service.Switch(""); err == nil {
+		t.Fatal("expected error switching to profile without credentials")
+	}
+}
+
+func TestLoad_Env(t *testing.T) {
+	dir := t.TempDir()
+	accessHash, _ := sdk.NewUserAccessHash(strings.Repeat("a", 256))
+	token, _ := sdk.NewToken(strings.Repeat("b", 256))
+	writeUser(t, dir, &sdk.Authorization{Id: sdk.NewUserId(1), AccessHash: accessHash, Token: token})
+
+	t.Setenv(userIdEnv, "2")
+	t.Setenv(tokenEnv, strings.Repeat("c", 256))
+	t.Setenv(accessHashEnv, strings.Repeat("d", 256))
+
+	service := NewService(nil).WithFolder(dir)
+	loaded, err := service.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Id.Value() != 2 || loaded.Token.Value() != strings.Repeat("c", 256) {
+		t.Fatalf("expected credentials from environment to take precedence, got %v", loaded)
+	}
+
+	if service.user != nil {
+		t.Fatal("expected credentials from environment not to be persisted")
+	}
+
+	t.Setenv(accessHashEnv, "")
+	_, err = service.load()
+	if err == nil || !strings.Contains(err.Error(), accessHashEnv) {
+		t.Fatalf("expected error naming %s, got %v", accessHashEnv, err)
+	}
+}
+
+// uploader records avatar passed to Register after UploadFile.
+type uploader struct {
+	uploads int
+	avatar  *sdk.FileDescriptor
+}
+
+func (u *uploader) Register(_ context.Context, _ sdk.Nickname, _ sdk.UserDescription, _ sdk.Interests, avatar *sdk.FileDescriptor, _ sdk.SocialLink) (*sdk.Authorization, error) {
+	u.avatar = avatar
+	accessHash, _ := sdk.NewUserAccessHash(strings.Repeat("a", 256))
+	token, _ := sdk.NewToken(strings.Repeat("b", 256))
+	return &sdk.Authorization{Id: sdk.NewUserId(1), AccessHash: accessHash, Token: token}, nil
+}
+
+func (u *uploader) UploadFile(_ context.Context, _ string, reader io.Reader) (*sdk.FileDescriptor, error) {
+	u.uploads++
+	if _, err := io.ReadAll(reader); err != nil {
+		return nil, err
+	}
+
+	return &sdk.FileDescriptor{Id: sdk.NewFileId(7)}, nil
+}
+
+func TestRegister_Avatar(t *testing.T) {
+	dir := t.TempDir()
+	image := filepath.Join(dir, "avatar.png")
+	text := filepath.Join(dir, "avatar.txt")
+	if err := os.WriteFile(image, []byte("\x89PNG\r\n\x1a\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(text, []byte("not an image"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &uploader{}
+	service := NewService(client).WithFolder(dir)
+
+	_, err := service.register(context.Background(), "nick", "bio", []string{"go"}, "https://example.com", text)
+	if err == nil || client.uploads != 0 {
+		t.Fatalf("expected text file to be rejected before uploading, got %v after %d uploads", err, client.uploads)
+	}
+
+	_, err = service.register(context.Background(), "nick", "bio", []string{"go"}, "https://example.com", image)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.uploads != 1 || client.avatar == nil || client.avatar.Id.Value() != 7 {
+		t.Fatalf("expected uploaded avatar to be registered, got %+v after %d uploads", client.avatar, client.uploads)
+	}
+}
+
+func TestRegister_LargeAvatar(t *testing.T) {
+	dir := t.TempDir()
+	image := filepath.Join(dir, "avatar.png")
+	if err := os.WriteFile(image, []byte("\x89PNG\r\n\x1a\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(image, maxAvatarSize+1); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &uploader{}
+	_, err := NewService(client).WithFolder(dir).register(context.Background(), "nick", "bio", []string{"go"}, "https://example.com", image)
+	if err == nil || !strings.Contains(err.Error(), "at most") || client.uploads != 0 {
+		t.Fatalf("expected large avatar to be rejected before uploading, got %v after %d uploads", err, client.uploads)
+	}
+}
+
+func TestRegister_AllErrors(t *testing.T) {
+	client := &uploader{}
+	service := NewService(client).WithFolder(t.TempDir())
+
+	_, err := service.register(context.Background(), "", "", []string{"go", strings.Repeat("x", 1024)}, "", "")
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	// nickname, description, the long interest and social link are reported together, one per line
+	lines := strings.Split(err.Error(), "\n")
+	for i, field := range []string{"nickname", "description", "interest", "social link"} {
+		if i >= len(lines) || !strings.Contains(lines[i], field) {
+			t.Fatalf("expected line %d to be about %s, got %q", i, field, err.Error())
+		}
+	}
+
+	if client.avatar != nil || client.uploads != 0 {
+		t.Fatal("expected nothing to be sent to the server")
+	}
+}
@@ -0,0 +1,368 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/ui"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// refreshMsg asks the Screen to reload pending requests.
+type refreshMsg struct{}
+
+// loadedMsg delivers freshly loaded requests to the Screen, or error if loading failed.
+type loadedMsg struct {
+	load     int
+	requests []sdk.UserDetails
+	err      error
+}
+
+// answerMsg asks the Screen to accept or decline all pending requests.
+type answerMsg struct {
+	accept bool
+}
+
+// answeredMsg delivers result of a single answered request. Closed results mean all of them are answered.
+type answeredMsg struct {
+	results <-chan error
+	err     error
+	done    bool
+}
+
+// declinedMsg signalizes that declining request of a single user finished, successfully if err is nil.
+type declinedMsg struct {
+	details sdk.UserDetails
+	err     error
+}
+
+// Screen is a model of friend requests screen.
+type Screen struct {
+	service  *Service
+	user     *sdk.Authorization
+	requests []sdk.UserDetails
+	loads    int
+	loading  bool
+	err      error
+
+	// progress of the running bulk answer, answering is false if there's none
+	answering bool
+	accept    bool
+	answered  int
+	failed    []error
+
+	// confirming is the user whose request is declined once d is pressed again
+	confirming *sdk.UserId
+
+	width int
+
+	content struct {
+		list   *ui.List
+		status *ui.Label
+		result *ui.Label
+
+		button struct {
+			accept  *ui.Button
+			decline *ui.Button
+			refresh *ui.Button
+			back    *ui.Button
+		}
+	}
+}
+
+// New creates new Screen from Service.
+func New(service *Service) Screen {
+	result := Screen{
+		service: service,
+	}
+
+	result.content.status = ui.NewLabel("log in to see your friend requests")
+	result.content.result = ui.NewLabel("")
+	result.content.button.accept = ui.NewButton("Accept all", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeRequests, Inner: answerMsg{accept: true}}
+	})
+	result.content.button.decline = ui.NewButton("Decline all", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeRequests, Inner: answerMsg{accept: false}}
+	})
+	result.content.button.refresh = ui.NewButton("Refresh", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeRequests, Inner: refreshMsg{}}
+	})
+	result.content.button.back = ui.NewButton("Back", func() tea.Msg {
+		return screen.BackMsg{}
+	})
+
+	result.content.list = ui.NewList(result.items()...)
+	return result
+}
+
+func (Screen) ID() screen.Type {
+	return screen.TypeRequests
+}
+
+func (s Screen) Init() tea.Cmd {
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: ui.SelectMsg{}}
+	}
+}
+
+func (s Screen) load() (Screen, tea.Cmd) {
+	if s.user == nil {
+		return s, nil
+	}
+
+	s.loads++
+	load := s.loads
+	s.loading = true
+	s.content.status.Set(s.summary())
+
+	return s, func() tea.Msg {
+		requests, err := s.service.pending(context.Background(), s.user)
+		return router.TargetMsg{Type: s.ID(), Inner: loadedMsg{load: load, requests: requests, err: err}}
+	}
+}
+
+// items returns users who sent requests followed by controls.
+func (s Screen) items() []tea.Model {
+	items := make([]tea.Model, 0, len(s.requests)+4)
+	for _, details := range s.requests {
+		prefix := fmt.Sprintf("%s %s: ", ui.Avatar(details.Nickname.Value(), details.Avatar != nil), details.Nickname.Value())
+		items = append(items, ui.NewButton(
+			ui.Clamp(prefix, details.Description.Value(), "", s.width-ui.ListIndent, ui.SummaryLines), nil))
+	}
+
+	return append(items,
+		s.content.button.accept,
+		s.content.button.decline,
+		s.content.button.refresh,
+		s.content.button.back)
+}
+
+// answer starts accepting or declining all loaded requests.
+func (s Screen) answer(accept bool) (Screen, tea.Cmd) {
+	if s.answering || len(s.requests) == 0 {
+		return s, nil
+	}
+
+	s.answering = true
+	s.accept = accept
+	s.answered = 0
+	s.failed = nil
+	s.content.result.Set("")
+	s.content.status.Set(s.progress())
+
+	results := s.service.answer(context.Background(), s.user, s.requests, accept)
+	return s, wait(results)
+}
+
+// wait delivers the next result of answering requests.
+func wait(results <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		err, ok := <-results
+		return router.TargetMsg{Type: screen.TypeRequests, Inner: answeredMsg{results: results, err: err, done: !ok}}
+	}
+}
+
+// summary describes state of the requests or number of them.
+func (s Screen) summary() string {
+	if state, ok := ui.ListState(len(s.requests), s.err, s.loading, "no pending requests"); !ok {
+		return state
+	}
+
+	return fmt.Sprintf("%d pending requests", len(s.requests))
+}
+
+func (s Screen) progress() string {
+	verb := "declining"
+	if s.accept {
+		verb = "accepting"
+	}
+
+	return fmt.Sprintf("%s friend requests... %d/%d", verb, s.answered, len(s.requests))
+}
+
+// outcome describes finished bulk answer.
+func (s Screen) outcome() string {
+	verb := "declined"
+	if s.accept {
+		verb = "accepted"
+	}
+
+	text := fmt.Sprintf("%s %d of %d friend requests", verb, s.answered-len(s.failed), s.answered)
+	if len(s.failed) == 0 {
+		return text
+	}
+
+	return fmt.Sprintf("%s, %d failed", text, len(s.failed))
+}
+
+// decline asks to confirm declining request of the selected user, declining it if it's already confirmed.
+func (s Screen) decline() (Screen, tea.Cmd) {
+	index := s.content.list.Cursor()
+	if s.answering || index >= len(s.requests) {
+		return s, nil
+	}
+
+	details := s.requests[index]
+	if s.confirming == nil || *s.confirming != details.Id {
+		s.confirming = &details.Id
+		s.content.result.Set(fmt.Sprintf("decline friend request from %s? they won't become your friend, "+
+			"existing friends aren't affected. press d again to confirm, esc to cancel", details.Nickname.Value()))
+		return s, nil
+	}
+
+	s.confirming = nil
+	s.content.result.Set(fmt.Sprintf("declining friend request from %s...", details.Nickname.Value()))
+	return s, func() tea.Msg {
+		ctx := context.Background()
+		friends, err := s.service.friends(ctx, s.user)
+		if err == nil {
+			err = s.service.decline(ctx, s.user, details, friends)
+		}
+
+		return router.TargetMsg{Type: s.ID(), Inner: declinedMsg{details: details, err: err}}
+	}
+}
+
+func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		return s, s.content.list.Replace(s.items()...)
+	case auth.LoginMsg:
+		s.user = msg.User
+		return s.load()
+	case refreshMsg:
+		if s.answering {
+			return s, nil
+		}
+
+		return s.load()
+	case loadedMsg:
+		if msg.load != s.loads {
+			return s, nil
+		}
+
+		s.loading = false
+		s.err = msg.err
+		if msg.err != nil {
+			s.content.status.Set(s.summary())
+			return s, func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+			}
+		}
+
+		s.requests = msg.requests
+		s.content.status.Set(s.summary())
+		return s, s.content.list.Set(s.items()...)
+	case answerMsg:
+		return s.answer(msg.accept)
+	case declinedMsg:
+		if msg.err != nil {
+			s.content.result.Set(ui.ErrorStyle().Render(msg.err.Error()))
+			return s, func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+			}
+		}
+
+		text := fmt.Sprintf("declined friend request from %s", msg.details.Nickname.Value())
+		s.content.result.Set(text)
+		log := func() tea.Msg {
+			return router.LogMsg{Severity: router.SeveritySuccess, Text: text}
+		}
+
+		index := slices.IndexFunc(s.requests, func(details sdk.UserDetails) bool {
+			return details.Id == msg.details.Id
+		})
+		if index < 0 {
+			return s, log
+		}
+
+		s.requests = slices.Delete(s.requests, index, index+1)
+		s.content.status.Set(s.summary())
+		return s, tea.Batch(s.content.list.Remove(index), log)
+	case answeredMsg:
+		if !msg.done {
+			s.answered++
+			if msg.err != nil {
+				s.failed = append(s.failed, msg.err)
+			}
+
+			s.content.status.Set(s.progress())
+			return s, wait(msg.results)
+		}
+
+		s.answering = false
+		outcome := s.outcome()
+		severity := router.SeveritySuccess
+		if len(s.failed) != 0 {
+			severity = router.SeverityError
+			outcome += ": " + errors.Join(s.failed...).Error()
+		}
+
+		// answered requests leave the feed, so reloading shows only the failed ones
+		s.content.result.Set(s.outcome())
+		var cmd tea.Cmd
+		s, cmd = s.load()
+		return s, tea.Batch(cmd, func() tea.Msg {
+			return router.LogMsg{Severity: severity, Text: outcome}
+		})
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "d":
+			return s.decline()
+		case "r":
+			if s.err != nil && !s.answering {
+				return s.load()
+			}
+		case "esc":
+			if s.confirming != nil {
+				s.confirming = nil
+				s.content.result.Set("")
+				return s, nil
+			}
+
+			return s, func() tea.Msg {
+				return screen.BackMsg{}
+			}
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
+	_, cmd := s.content.list.Update(msg)
+	return s, cmd
+}
+
+func (s Screen) Debug() any {
+	return s.requests
+}
+
+func (Screen) Bindings() []ui.Binding {
+	return []ui.Binding{
+		{Key: "d", Action: "decline selected request, press twice to confirm"},
+		{Key: "r", Action: "retry failed loading"},
+		{Key: "esc", Action: "cancel declining"},
+	}
+}
+
+func (s Screen) header() string {
+	return lipgloss.JoinVertical(lipgloss.Left, "friend requests screen (d to decline selected)", s.content.status.View(), "")
+}
+
+func (s Screen) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.header(),
+		s.content.list.View(),
+		"",
+		s.content.result.View(),
+	)
+}
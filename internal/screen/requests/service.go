@@ -0,0 +1,131 @@
+package requests
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// parallelism is the maximum number of requests answered at the same time.
+const parallelism = 4
+
+// Client is a subset of sdk.Client methods used by Service.
+type Client interface {
+	GetFeedQueue(ctx context.Context, auth *sdk.Authorization) (*sdk.FeedQueue, error)
+	SendFriendRequest(ctx context.Context, auth *sdk.Authorization, userId sdk.UserId, accessHash sdk.UserAccessHash) error
+	DeclineFriendRequest(ctx context.Context, auth *sdk.Authorization, userId sdk.UserId, accessHash sdk.UserAccessHash) error
+	GetNetworkDetails(ctx context.Context, auth *sdk.Authorization) (*sdk.NetworkDetails, error)
+}
+
+// Service provides logic of handling incoming friend requests.
+type Service struct {
+	client Client
+}
+
+// NewService creates new Service from client.
+func NewService(client Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// pending returns users who sent friend request to user.
+func (s *Service) pending(ctx context.Context, user *sdk.Authorization) ([]sdk.UserDetails, error) {
+	queue, err := s.client.GetFeedQueue(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("requests: failed to get feed queue: %w", err)
+	}
+
+	requests := make([]sdk.UserDetails, 0)
+	for _, entry := range queue.Entries {
+		if entry.IsRequest {
+			requests = append(requests, entry.Details)
+		}
+	}
+
+	return requests, nil
+}
+
+// friends returns IDs of users who are already user's friends. Declining is only meant for pending requests,
+// so they are never declined, even if the feed is outdated and still lists their request.
+func (s *Service) friends(ctx context.Context, user *sdk.Authorization) (map[sdk.UserId]bool, error) {
+	network, err := s.client.GetNetworkDetails(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("requests: failed to get network details: %w", err)
+	}
+
+	friends := make(map[sdk.UserId]bool, len(network.Friends))
+	for _, friend := range network.Friends {
+		friends[friend.Id] = true
+	}
+
+	return friends, nil
+}
+
+// answer accepts or declines requests of users, at most parallelism of them at once.
+// Result of every request is sent to the returned channel, which is closed once all of them are answered.
+func (s *Service) answer(ctx context.Context, user *sdk.Authorization, users []sdk.UserDetails, accept bool) <-chan error {
+	results := make(chan error, len(users))
+	slots := make(chan struct{}, parallelism)
+
+	go func() {
+		defer close(results)
+
+		friends := map[sdk.UserId]bool{}
+		if !accept {
+			var err error
+			friends, err = s.friends(ctx, user)
+			if err != nil {
+				for range users {
+					results <- err
+				}
+
+				return
+			}
+		}
+
+		var wg sync.WaitGroup
+		for _, details := range users {
+			wg.Go(func() {
+				slots <- struct{}{}
+				defer func() { <-slots }()
+
+				if accept {
+					results <- s.accept(ctx, user, details)
+					return
+				}
+
+				results <- s.decline(ctx, user, details, friends)
+			})
+		}
+		wg.Wait()
+	}()
+
+	return results
+}
+
+// accept accepts request of a single user by sending one back.
+func (s *Service) accept(ctx context.Context, user *sdk.Authorization, details sdk.UserDetails) error {
+	err := s.client.SendFriendRequest(ctx, user, details.Id, details.AccessHash)
+	if err != nil {
+		return fmt.Errorf("requests: failed to accept request of %s: %w", details.Nickname.Value(), err)
+	}
+
+	return nil
+}
+
+// decline declines pending request of a single user, refusing to touch users listed in friends.
+func (s *Service) decline(ctx context.Context, user *sdk.Authorization, details sdk.UserDetails, friends map[sdk.UserId]bool) error {
+	if friends[details.Id] {
+		return fmt.Errorf("requests: %s is already your friend, declining a request never removes friends", details.Nickname.Value())
+	}
+
+	err := s.client.DeclineFriendRequest(ctx, user, details.Id, details.AccessHash)
+	if err != nil {
+		return fmt.Errorf("requests: failed to decline request of %s: %w", details.Nickname.Value(), err)
+	}
+
+	return nil
+}
@@ -9,6 +9,14 @@ const (
 	TypeRegister Type = "register"
 	TypeHome     Type = "home"
 	TypeProfile  Type = "profile"
+	TypeFeed     Type = "feed"
+	TypeEdit     Type = "edit"
+	TypeFriend   Type = "friend"
+	TypeIntro    Type = "intro"
+	TypeNetwork  Type = "network"
+	TypeRequests Type = "requests"
+	TypeToken    Type = "token"
+	TypeAccounts Type = "accounts"
 )
 
 // Model represents Screen which is basically an extended tea.Model.
@@ -19,3 +27,9 @@ type Model interface {
 	Update(tea.Msg) (Model, tea.Cmd)
 	View() string
 }
+
+// Debugger is implemented by screens which can expose data behind their view for debugging.
+type Debugger interface {
+	// Debug returns data rendered by the screen, which is marshalled to JSON as is.
+	Debug() any
+}
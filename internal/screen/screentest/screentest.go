@@ -0,0 +1,155 @@
+// Package screentest drives screens without tea.Program, so their rendered views can be compared with golden files.
+package screentest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with rendered views")
+
+// Driver delivers messages to a screen and runs commands it returns, like tea.Program does without a terminal.
+// Commands waiting for the fake clock stay parked until Advance fires them, so timers never race the test.
+type Driver struct {
+	model   screen.Model
+	clock   *clock.Fake
+	results chan tea.Msg
+	running int
+	skipped []tea.Msg
+}
+
+// New creates Driver of model. Fake must be the clock driving timers of model, nil if it has none.
+func New(model screen.Model, fake *clock.Fake) *Driver {
+	return &Driver{
+		model:   model,
+		clock:   fake,
+		results: make(chan tea.Msg),
+	}
+}
+
+// Send delivers msgs to the screen and runs produced commands until each of them finishes or waits for the clock.
+func (d *Driver) Send(msgs ...tea.Msg) {
+	for _, msg := range msgs {
+		d.deliver(msg)
+	}
+
+	d.settle()
+}
+
+// Advance moves the fake clock by dur and runs commands woken by it the same way as Send.
+func (d *Driver) Advance(dur time.Duration) {
+	d.clock.Advance(dur)
+	d.settle()
+}
+
+// View renders the screen.
+func (d *Driver) View() string {
+	return d.model.View()
+}
+
+// Skipped returns messages for the router or other screens in order they were produced.
+func (d *Driver) Skipped() []tea.Msg {
+	return d.skipped
+}
+
+func (d *Driver) deliver(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case nil:
+		return
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			d.start(cmd)
+		}
+
+		return
+	case router.TargetMsg:
+		if msg.Type != d.model.ID() {
+			d.skipped = append(d.skipped, msg)
+			return
+		}
+
+		d.deliver(msg.Inner)
+		return
+	case router.BroadcastMsg:
+		d.deliver(msg.Inner)
+		return
+	case router.StatusMsg, router.RequestsMsg, router.PollingMsg, router.ConnectionMsg, router.LogMsg,
+		screen.ChangeMsg, screen.BackMsg:
+		// router handles these itself, screens never receive them
+		d.skipped = append(d.skipped, msg)
+		return
+	}
+
+	var cmd tea.Cmd
+	d.model, cmd = d.model.Update(msg)
+	d.start(cmd)
+}
+
+func (d *Driver) start(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+
+	d.running++
+	go func() {
+		d.results <- cmd()
+	}()
+}
+
+// parked returns number of commands waiting for the fake clock.
+func (d *Driver) parked() int {
+	if d.clock == nil {
+		return 0
+	}
+
+	return d.clock.Waiters()
+}
+
+// settle delivers results of commands until the rest of them are parked on the fake clock. Fake clock doesn't
+// report parking, so it's checked again every millisecond instead, which only delays settling and never drops results.
+func (d *Driver) settle() {
+	for d.running > d.parked() {
+		select {
+		case msg := <-d.results:
+			d.running--
+			d.deliver(msg)
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// Golden compares view with testdata/name.golden, rewriting the file instead when tests run with -update, for example
+// go test ./internal/screen/feed -update.
+func Golden(t *testing.T, name, view string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(view), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file, run tests with -update to create it: %v", err)
+	}
+
+	if string(want) != view {
+		t.Fatalf("view doesn't match %s, run tests with -update if the change is intended\ngot:\n%s\nwant:\n%s", path, view, want)
+	}
+}
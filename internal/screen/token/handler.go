@@ -0,0 +1,193 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/router"
+	"github.com/friendly-social/cli/internal/screen"
+	"github.com/friendly-social/cli/internal/screen/auth"
+	"github.com/friendly-social/cli/internal/ui"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// OpenMsg asks the Screen to generate share link and become the current screen.
+type OpenMsg struct{}
+
+// generateMsg asks the Screen to generate new share link, replacing the current one.
+type generateMsg struct{}
+
+// generatedMsg delivers freshly generated share link to the Screen, or error if generating failed.
+type generatedMsg struct {
+	generation int
+	link       string
+	err        error
+}
+
+// tickMsg refreshes age of the share link created by provided generation.
+type tickMsg struct {
+	generation int
+}
+
+// Screen is a model of share link screen.
+type Screen struct {
+	service     *Service
+	user        *sdk.Authorization
+	generations int
+
+	link        string
+	generatedAt time.Time
+
+	content struct {
+		list   *ui.List
+		status *ui.Label
+
+		button struct {
+			regenerate *ui.Button
+			back       *ui.Button
+		}
+	}
+}
+
+// New creates new Screen from Service.
+func New(service *Service) Screen {
+	result := Screen{
+		service: service,
+	}
+
+	result.content.status = ui.NewLabel("")
+	result.content.button.regenerate = ui.NewButton("Regenerate", func() tea.Msg {
+		return router.TargetMsg{Type: screen.TypeToken, Inner: generateMsg{}}
+	})
+	result.content.button.back = ui.NewButton("Back", func() tea.Msg {
+		return screen.BackMsg{}
+	})
+
+	result.content.list = ui.NewList(
+		result.content.button.regenerate,
+		result.content.button.back)
+
+	return result
+}
+
+func (Screen) ID() screen.Type {
+	return screen.TypeToken
+}
+
+func (s Screen) Init() tea.Cmd {
+	return func() tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: ui.SelectMsg{}}
+	}
+}
+
+// generate requests new share link. Age of the previous one stops being tracked.
+func (s Screen) generate() (Screen, tea.Cmd) {
+	if s.user == nil {
+		s.content.status.Set("log in to share your profile")
+		return s, nil
+	}
+
+	s.generations++
+	generation := s.generations
+	s.content.status.Set("generating share link...")
+
+	return s, func() tea.Msg {
+		link, err := s.service.link(context.Background(), s.user)
+		return router.TargetMsg{Type: s.ID(), Inner: generatedMsg{generation: generation, link: link, err: err}}
+	}
+}
+
+func (s Screen) tick(generation int) tea.Cmd {
+	return clock.Tick(s.service.clock, time.Second, func(time.Time) tea.Msg {
+		return router.TargetMsg{Type: s.ID(), Inner: tickMsg{generation: generation}}
+	})
+}
+
+func (s Screen) Update(msg tea.Msg) (screen.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case auth.LoginMsg:
+		s.user = msg.User
+		s.link = ""
+		return s, nil
+	case OpenMsg:
+		var cmd tea.Cmd
+		s, cmd = s.generate()
+		return s, tea.Batch(cmd, func() tea.Msg {
+			return screen.ChangeMsg{NewType: s.ID()}
+		})
+	case generateMsg:
+		return s.generate()
+	case generatedMsg:
+		if msg.generation != s.generations {
+			return s, nil
+		}
+
+		if msg.err != nil {
+			s.content.status.Set(ui.ErrorStyle().Render(msg.err.Error() + " (r to retry)"))
+			return s, func() tea.Msg {
+				return router.LogMsg{Severity: router.SeverityError, Text: msg.err.Error()}
+			}
+		}
+
+		s.link = msg.link
+		s.generatedAt = s.service.clock.Now()
+		s.content.status.Set("")
+		return s, s.tick(msg.generation)
+	case tickMsg:
+		// View renders the age, so the tick only has to keep going while the link is current
+		if msg.generation != s.generations {
+			return s, nil
+		}
+
+		return s, s.tick(msg.generation)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return s.generate()
+		case "esc":
+			return s, func() tea.Msg {
+				return screen.BackMsg{}
+			}
+		}
+	case tea.MouseMsg:
+		_, cmd := s.content.list.Update(ui.ShiftMouse(msg, s.header()))
+		return s, cmd
+	}
+
+	_, cmd := s.content.list.Update(msg)
+	return s, cmd
+}
+
+func (Screen) Bindings() []ui.Binding {
+	return []ui.Binding{
+		{Key: "r", Action: "generate new share link"},
+	}
+}
+
+func (s Screen) header() string {
+	lines := []string{"share link screen (r to regenerate)", ""}
+	if s.link != "" {
+		age := clock.Since(s.service.clock, s.generatedAt).Truncate(time.Second)
+		lines = append(lines,
+			"send this link to your friend, they paste it on their add friend screen:",
+			s.link,
+			fmt.Sprintf("generated %s ago", age),
+			"")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (s Screen) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.header(),
+		s.content.list.View(),
+		"",
+		s.content.status.View(),
+	)
+}
@@ -0,0 +1,45 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/friendly-social/cli/internal/clock"
+	"github.com/friendly-social/cli/internal/share"
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// Client is a subset of sdk.Client methods used by Service.
+type Client interface {
+	GenerateFriendToken(ctx context.Context, auth *sdk.Authorization) (sdk.FriendToken, error)
+}
+
+// Service provides logic of generating share links.
+type Service struct {
+	client Client
+	clock  clock.Clock
+}
+
+// NewService creates new Service from client.
+func NewService(client Client) *Service {
+	return &Service{
+		client: client,
+		clock:  clock.Real{},
+	}
+}
+
+// WithClock sets clock measuring age of the generated link.
+func (s *Service) WithClock(c clock.Clock) *Service {
+	s.clock = c
+	return s
+}
+
+// link generates friend token and packs it with user's ID into a share link.
+func (s *Service) link(ctx context.Context, user *sdk.Authorization) (string, error) {
+	token, err := s.client.GenerateFriendToken(ctx, user)
+	if err != nil {
+		return "", fmt.Errorf("token: failed to generate friend token: %w", err)
+	}
+
+	return share.Link(token, user.Id), nil
+}
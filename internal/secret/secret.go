@@ -0,0 +1,76 @@
+// Package secret hides credentials before they're shown on screen or written to logs.
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// visible is the number of characters kept on each side of a masked value.
+const visible = 4
+
+// keys are JSON keys of secret values in sdk types.
+var keys = map[string]bool{
+	"token":      true,
+	"accessHash": true,
+}
+
+// Mask keeps first and last few characters of value, hiding the rest.
+// Values too short to keep anything hidden are masked completely.
+func Mask(value string) string {
+	if len(value) <= 4*visible {
+		return strings.Repeat("*", len(value))
+	}
+
+	return value[:visible] + "…" + value[len(value)-visible:]
+}
+
+// Authorization describes auth with masked token and access hash.
+func Authorization(auth *sdk.Authorization) string {
+	if auth == nil {
+		return "not logged in"
+	}
+
+	return fmt.Sprintf("user %d (token %s, access hash %s)",
+		auth.Id.Value(), Mask(auth.Token.Value()), Mask(auth.AccessHash.Value()))
+}
+
+// Redact returns JSON representation of value with every token and access hash masked.
+// Value which can't be marshalled is returned as is, so the caller reports the error.
+func Redact(value any) any {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var document any
+	err = json.Unmarshal(bytes, &document)
+	if err != nil {
+		return value
+	}
+
+	return redact(document)
+}
+
+func redact(document any) any {
+	switch document := document.(type) {
+	case map[string]any:
+		for key, value := range document {
+			if text, ok := value.(string); ok && keys[key] {
+				document[key] = Mask(text)
+				continue
+			}
+
+			document[key] = redact(value)
+		}
+	case []any:
+		for i, value := range document {
+			document[i] = redact(value)
+		}
+	}
+
+	return document
+}
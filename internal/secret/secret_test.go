@@ -0,0 +1,44 @@
+package secret
+
+import (
+	"strings"
+	"testing"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+func TestMask(t *testing.T) {
+	tests := map[string]string{
+		"":                      "",
+		"short":                 "*****",
+		strings.Repeat("a", 16): strings.Repeat("*", 16),
+		"abcd" + strings.Repeat("x", 248) + "wxyz": "abcd…wxyz",
+	}
+
+	for value, expected := range tests {
+		if masked := Mask(value); masked != expected {
+			t.Fatalf("expected %q, got %q", expected, masked)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	accessHash, _ := sdk.NewUserAccessHash(strings.Repeat("a", 256))
+	token, _ := sdk.NewToken(strings.Repeat("b", 256))
+	nickname, _ := sdk.NewNickname("alice")
+	value := map[string]any{
+		"user":    &sdk.Authorization{Id: sdk.NewUserId(1), AccessHash: accessHash, Token: token},
+		"friends": []sdk.UserDetails{{Id: sdk.NewUserId(2), Nickname: nickname, AccessHash: accessHash}},
+	}
+
+	redacted := Redact(value).(map[string]any)
+	user := redacted["user"].(map[string]any)
+	if user["token"] != "bbbb…bbbb" || user["accessHash"] != "aaaa…aaaa" {
+		t.Fatalf("expected credentials to be masked, got %v", user)
+	}
+
+	friend := redacted["friends"].([]any)[0].(map[string]any)
+	if friend["accessHash"] != "aaaa…aaaa" || friend["nickname"] != "alice" {
+		t.Fatalf("expected only access hash of friend to be masked, got %v", friend)
+	}
+}
@@ -0,0 +1,50 @@
+// Package share packs everything needed to add a friend into a single string.
+package share
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+// payload is JSON encoded into a share link.
+type payload struct {
+	Token string `json:"token"`
+	Id    int64  `json:"id"`
+}
+
+// Link packs friend token together with ID of its owner, since AddFriend needs both.
+func Link(token sdk.FriendToken, id sdk.UserId) string {
+	// marshaling of a string and a number can't fail
+	bytes, _ := json.Marshal(payload{Token: token.Value(), Id: id.Value()})
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// Clean removes all whitespace from link, which terminals tend to insert when long links are pasted.
+func Clean(link string) string {
+	return strings.Join(strings.Fields(link), "")
+}
+
+// ParseLink unpacks friend token and user ID from link created by Link, ignoring whitespace in it.
+func ParseLink(link string) (sdk.FriendToken, sdk.UserId, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(Clean(link))
+	if err != nil {
+		return sdk.FriendToken{}, sdk.UserId{}, fmt.Errorf("share: link is malformed: %w", err)
+	}
+
+	var value payload
+	err = json.Unmarshal(bytes, &value)
+	if err != nil {
+		return sdk.FriendToken{}, sdk.UserId{}, fmt.Errorf("share: link is malformed: %w", err)
+	}
+
+	token, err := sdk.NewFriendToken(value.Token)
+	if err != nil {
+		return sdk.FriendToken{}, sdk.UserId{}, fmt.Errorf("share: link has invalid token: %w", err)
+	}
+
+	return token, sdk.NewUserId(value.Id), nil
+}
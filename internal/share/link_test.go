@@ -0,0 +1,54 @@
+package share
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+func TestLink_RoundTrip(t *testing.T) {
+	token, _ := sdk.NewFriendToken(strings.Repeat("t", 256))
+	id := sdk.NewUserId(42)
+
+	parsedToken, parsedId, err := ParseLink(Link(token, id))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsedToken != token || parsedId != id {
+		t.Fatalf("expected %v and %v, got %v and %v", token, id, parsedToken, parsedId)
+	}
+}
+
+func TestParseLink_Whitespace(t *testing.T) {
+	token, _ := sdk.NewFriendToken(strings.Repeat("t", 256))
+	link := Link(token, sdk.NewUserId(42))
+
+	// pasted into a narrow terminal, the link gets broken into lines
+	pasted := " " + link[:100] + "\n" + link[100:200] + "\r\n " + link[200:] + "\t"
+	if _, _, err := ParseLink(pasted); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseLink_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		link string
+		err  error
+	}{
+		"not base64":  {link: "!!!"},
+		"not json":    {link: "bm90IGpzb24"},
+		"short token": {link: Link(sdk.FriendToken{}, sdk.NewUserId(1)), err: sdk.ErrFriendTokenLengthMustBe256},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := ParseLink(test.link)
+			if err == nil || (test.err != nil && !errors.Is(err, test.err)) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
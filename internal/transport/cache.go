@@ -0,0 +1,163 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/friendly-social/cli/internal/clock"
+)
+
+// cached is a response stored by Cache.
+type cached struct {
+	// generation is the one of Cache when request of the response was sent
+	generation uint64
+	at         time.Time
+	status     int
+	header     http.Header
+	body       []byte
+}
+
+// Cache is an http.RoundTripper which serves repeated GET requests of JSON from memory for ttl.
+// Any other request, like sending a friend request, may change what GET requests return,
+// so it invalidates the whole cache. Responses to GET requests in flight during invalidation may be stale
+// and aren't stored.
+type Cache struct {
+	next  http.RoundTripper
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]cached
+	// generation counts invalidations
+	generation uint64
+}
+
+// NewCache creates new Cache which wraps next http.RoundTripper. Zero ttl disables caching.
+func NewCache(next http.RoundTripper, ttl time.Duration) *Cache {
+	return &Cache{
+		next:    next,
+		ttl:     ttl,
+		clock:   clock.Real{},
+		entries: make(map[string]cached),
+	}
+}
+
+// WithClock sets clock deciding when cached responses expire.
+func (c *Cache) WithClock(clk clock.Clock) *Cache {
+	c.clock = clk
+	return c
+}
+
+type cacheReportKey struct{}
+
+// TrackCache returns ctx under which Cache reports whether response was served from memory.
+// Value of the returned flag is meaningful once the request using ctx completes.
+func TrackCache(ctx context.Context) (context.Context, *atomic.Bool) {
+	fromCache := new(atomic.Bool)
+	return context.WithValue(ctx, cacheReportKey{}, fromCache), fromCache
+}
+
+// key separates cached responses of different users.
+func key(req *http.Request) string {
+	return req.URL.String() + "\x00" + req.Header.Get("X-User-Id") + "\x00" + req.Header.Get("X-Token")
+}
+
+// cacheable reports whether resp is a successful JSON response, downloaded files are never kept in memory.
+func cacheable(resp *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return resp.StatusCode == http.StatusOK && err == nil && mediaType == "application/json"
+}
+
+func (c *Cache) lookup(req *http.Request) (cached, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key(req)]
+	if !ok || clock.Since(c.clock, entry.at) >= c.ttl {
+		return cached{}, false
+	}
+
+	return entry, true
+}
+
+func (c *Cache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.generation++
+}
+
+// current returns generation of the cache.
+func (c *Cache) current() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// store keeps entry for req unless the cache was invalidated since the request was sent.
+func (c *Cache) store(req *http.Request, entry cached) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry.generation == c.generation {
+		c.entries[key(req)] = entry
+	}
+}
+
+func (c *Cache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if c.ttl == 0 {
+		return c.next.RoundTrip(req)
+	}
+
+	if req.Method != http.MethodGet {
+		// GET requests sent while the server is processing req may see state before or after it,
+		// so invalidating once it completes keeps their responses out of the cache
+		c.invalidate()
+		defer c.invalidate()
+		return c.next.RoundTrip(req)
+	}
+
+	if entry, ok := c.lookup(req); ok {
+		if fromCache, ok := req.Context().Value(cacheReportKey{}).(*atomic.Bool); ok {
+			fromCache.Store(true)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", entry.status, http.StatusText(entry.status)),
+			StatusCode:    entry.status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}, nil
+	}
+
+	generation := c.current()
+	resp, err := c.next.RoundTrip(req)
+	if err != nil || !cacheable(resp) {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("transport: failed to read response to cache: %w", err)
+	}
+
+	c.store(req, cached{generation: generation, at: c.clock.Now(), status: resp.StatusCode, header: resp.Header.Clone(), body: body})
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/friendly-social/cli/internal/clock"
+)
+
+func TestCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	fake := clock.NewFake(time.Time{})
+	client := &http.Client{Transport: NewCache(http.DefaultTransport, time.Minute).WithClock(fake)}
+	request := func(method, token string) bool {
+		ctx, fromCache := TrackCache(context.Background())
+		req, err := http.NewRequestWithContext(ctx, method, server.URL+"/feed/queue", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("X-Token", token)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+
+		return fromCache.Load()
+	}
+
+	steps := []struct {
+		name      string
+		method    string
+		token     string
+		advance   time.Duration
+		fromCache bool
+		calls     int
+	}{
+		{name: "first", method: http.MethodGet, token: "a", calls: 1},
+		{name: "repeated", method: http.MethodGet, token: "a", fromCache: true, calls: 1},
+		{name: "another user", method: http.MethodGet, token: "b", calls: 2},
+		{name: "expired", method: http.MethodGet, token: "a", advance: time.Minute, calls: 3},
+		{name: "mutation", method: http.MethodPost, token: "a", calls: 4},
+		{name: "invalidated", method: http.MethodGet, token: "a", calls: 5},
+	}
+
+	for _, step := range steps {
+		fake.Advance(step.advance)
+		if fromCache := request(step.method, step.token); fromCache != step.fromCache {
+			t.Fatalf("%s: expected fromCache %t, got %t", step.name, step.fromCache, fromCache)
+		}
+
+		if calls != step.calls {
+			t.Fatalf("%s: expected %d calls to server, got %d", step.name, step.calls, calls)
+		}
+	}
+}
+
+func TestCache_InFlight(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	started, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+
+		// the first GET is answered only after POST invalidated the cache
+		if first {
+			close(started)
+			<-release
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewCache(http.DefaultTransport, time.Minute)}
+	request := func(method string) bool {
+		ctx, fromCache := TrackCache(context.Background())
+		req, err := http.NewRequestWithContext(ctx, method, server.URL+"/feed/queue", nil)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		_ = resp.Body.Close()
+
+		return fromCache.Load()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		request(http.MethodGet)
+	}()
+
+	<-started
+	request(http.MethodPost)
+	close(release)
+	<-done
+
+	if request(http.MethodGet) {
+		t.Fatal("expected response of GET in flight during invalidation not to be cached")
+	}
+
+	if !request(http.MethodGet) {
+		t.Fatal("expected response sent after invalidation to be cached")
+	}
+}
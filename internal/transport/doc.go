@@ -0,0 +1,2 @@
+// Package transport contains http.RoundTripper middlewares which extend behaviour of sdk.Client.
+package transport
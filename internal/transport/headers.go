@@ -0,0 +1,37 @@
+package transport
+
+import "net/http"
+
+// Headers is an http.RoundTripper which adds static headers to every request.
+// Headers already present in request, like Content-Type or X-Token, are never overwritten.
+// Accept-Encoding is dropped, since setting it disables transparent gzip decompression of http.Transport.
+type Headers struct {
+	next   http.RoundTripper
+	header http.Header
+}
+
+// NewHeaders creates new Headers which adds header to requests passed to next http.RoundTripper.
+func NewHeaders(next http.RoundTripper, header http.Header) *Headers {
+	header = header.Clone()
+	header.Del("Accept-Encoding")
+
+	return &Headers{
+		next:   next,
+		header: header,
+	}
+}
+
+func (h *Headers) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for key, values := range h.header {
+		if req.Header.Get(key) != "" {
+			continue
+		}
+
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	return h.next.RoundTrip(req)
+}
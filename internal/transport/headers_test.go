@@ -0,0 +1,110 @@
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHeaders(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = r.Header
+	}))
+	defer server.Close()
+
+	header := http.Header{}
+	header.Set("X-Api-Key", "secret")
+	header.Set("Content-Type", "text/plain")
+	header.Set("X-Token", "forged")
+	client := &http.Client{Transport: NewHeaders(http.DefaultTransport, header)}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{name: "json", contentType: "application/json", body: "{}"},
+		{name: "multipart", contentType: writer.FormDataContentType(), body: body.String()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(c.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set("Content-Type", c.contentType)
+			req.Header.Set("X-Token", "token")
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+
+			if got := received.Get("X-Api-Key"); got != "secret" {
+				t.Fatalf("expected custom header, got %q", got)
+			}
+
+			if got := received.Get("Content-Type"); got != c.contentType {
+				t.Fatalf("expected Content-Type %q to be kept, got %q", c.contentType, got)
+			}
+
+			if got := received.Get("X-Token"); got != "token" {
+				t.Fatalf("expected X-Token to be kept, got %q", got)
+			}
+
+			if req.Header.Get("X-Api-Key") != "" {
+				t.Fatal("expected original request to stay unmodified")
+			}
+		})
+	}
+}
+
+func TestHeaders_Gzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			_, _ = io.WriteString(w, `{"compressed":false}`)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		_, _ = io.WriteString(writer, `{"compressed":true}`)
+		_ = writer.Close()
+	}))
+	defer server.Close()
+
+	// manually set Accept-Encoding would leave the response compressed
+	header := http.Header{}
+	header.Set("Accept-Encoding", "gzip")
+	client := &http.Client{Transport: NewHeaders(http.DefaultTransport, header)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != `{"compressed":true}` {
+		t.Fatalf("expected decompressed gzip response, got %q", body)
+	}
+}
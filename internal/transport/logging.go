@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/friendly-social/cli/internal/clock"
+)
+
+// Logger receives summary of every performed request. Status is 0 if request failed before receiving response.
+type Logger func(method, path string, status int, dur time.Duration)
+
+// Logging is an http.RoundTripper which reports every request to Logger.
+// Only method, route, status and duration are reported, so neither headers like X-Token
+// nor access hashes in paths leak into logs.
+type Logging struct {
+	next   http.RoundTripper
+	logger Logger
+	clock  clock.Clock
+}
+
+// NewLogging creates new Logging which wraps next http.RoundTripper.
+func NewLogging(next http.RoundTripper, logger Logger) *Logging {
+	return &Logging{
+		next:   next,
+		logger: logger,
+		clock:  clock.Real{},
+	}
+}
+
+// WithClock sets clock measuring duration of requests.
+func (l *Logging) WithClock(c clock.Clock) *Logging {
+	l.clock = c
+	return l
+}
+
+func (l *Logging) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := l.clock.Now()
+	resp, err := l.next.RoundTrip(req)
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+
+	l.logger(req.Method, Route(req.URL.Path), status, clock.Since(l.clock, start))
+	return resp, err
+}
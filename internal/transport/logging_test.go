@@ -0,0 +1,45 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRoute(t *testing.T) {
+	hash := strings.Repeat("h", 256)
+	tests := map[string]string{
+		"/feed/queue":                        "/feed/queue",
+		"/users/details":                     "/users/details",
+		"/users/details/42/" + hash:          "/users/details/{id}/{hash}",
+		"/friendly/users/details/42/" + hash: "/friendly/users/details/{id}/{hash}",
+		"/files/download/7/" + hash:          "/files/download/{id}/{hash}",
+	}
+
+	for path, want := range tests {
+		if got := Route(path); got != want {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestLogging_HidesAccessHash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	var logged string
+	client := &http.Client{Transport: NewLogging(http.DefaultTransport, func(_, path string, _ int, _ time.Duration) {
+		logged = path
+	})}
+
+	hash := strings.Repeat("h", 256)
+	if err := get(t, client, server.URL+"/users/details/42/"+hash); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(logged, hash) || logged != "/users/details/{id}/{hash}" {
+		t.Fatalf("expected route without access hash to be logged, got %s", logged)
+	}
+}
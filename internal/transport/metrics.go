@@ -0,0 +1,105 @@
+package transport
+
+import (
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/friendly-social/cli/internal/clock"
+)
+
+// Metrics aggregates requests per endpoint, identified by its Route. Status is 0 if request failed before receiving response.
+type Metrics interface {
+	ObserveRequest(path string, status int, dur time.Duration)
+}
+
+// Metering is an http.RoundTripper which reports every request to Metrics.
+// Unlike Logging, it's meant for aggregate numbers rather than a line per request.
+type Metering struct {
+	next    http.RoundTripper
+	metrics Metrics
+	clock   clock.Clock
+}
+
+// NewMetering creates new Metering which wraps next http.RoundTripper.
+func NewMetering(next http.RoundTripper, metrics Metrics) *Metering {
+	return &Metering{
+		next:    next,
+		metrics: metrics,
+		clock:   clock.Real{},
+	}
+}
+
+// WithClock sets clock measuring duration of requests.
+func (m *Metering) WithClock(c clock.Clock) *Metering {
+	m.clock = c
+	return m
+}
+
+func (m *Metering) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := m.clock.Now()
+	resp, err := m.next.RoundTrip(req)
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+
+	m.metrics.ObserveRequest(Route(req.URL.Path), status, clock.Since(m.clock, start))
+	return resp, err
+}
+
+// Endpoint holds aggregated numbers of a single endpoint.
+type Endpoint struct {
+	Requests int
+	// Errors counts failed requests and responses with status 400 and above.
+	Errors int
+	Total  time.Duration
+	Max    time.Duration
+}
+
+// Mean returns average duration of a request.
+func (e Endpoint) Mean() time.Duration {
+	if e.Requests == 0 {
+		return 0
+	}
+
+	return e.Total / time.Duration(e.Requests)
+}
+
+// Collector is an in-memory Metrics safe for concurrent use.
+type Collector struct {
+	mu        sync.Mutex
+	endpoints map[string]Endpoint
+}
+
+// NewCollector creates new empty Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		endpoints: make(map[string]Endpoint),
+	}
+}
+
+func (c *Collector) ObserveRequest(path string, status int, dur time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	endpoint := c.endpoints[path]
+	endpoint.Requests++
+	if status == 0 || status >= 400 {
+		endpoint.Errors++
+	}
+
+	endpoint.Total += dur
+	endpoint.Max = max(endpoint.Max, dur)
+	c.endpoints[path] = endpoint
+}
+
+// Snapshot returns copy of numbers collected so far, keyed by path.
+func (c *Collector) Snapshot() map[string]Endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return maps.Clone(c.endpoints)
+}
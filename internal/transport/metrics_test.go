@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/friendly-social/cli/internal/clock"
+)
+
+func TestMetering(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	collector := NewCollector()
+	client := &http.Client{Transport: NewMetering(http.DefaultTransport, collector)}
+
+	for _, path := range []string{"/feed", "/feed", "/missing"} {
+		if err := get(t, client, server.URL+path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshot := collector.Snapshot()
+	if feed := snapshot["/feed"]; feed.Requests != 2 || feed.Errors != 0 {
+		t.Fatalf("expected 2 requests without errors to /feed, got %+v", feed)
+	}
+
+	if missing := snapshot["/missing"]; missing.Requests != 1 || missing.Errors != 1 {
+		t.Fatalf("expected 1 failed request to /missing, got %+v", missing)
+	}
+}
+
+func TestMetering_Route(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	collector := NewCollector()
+	client := &http.Client{Transport: NewMetering(http.DefaultTransport, collector)}
+
+	// every peeked user shares the same endpoint
+	for _, hash := range []string{strings.Repeat("a", 256), strings.Repeat("b", 256)} {
+		if err := get(t, client, server.URL+"/users/details/42/"+hash); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshot := collector.Snapshot()
+	if len(snapshot) != 1 || snapshot["/users/details/{id}/{hash}"].Requests != 2 {
+		t.Fatalf("expected 2 requests to a single route, got %+v", snapshot)
+	}
+}
+
+func TestMetering_Duration(t *testing.T) {
+	fake := clock.NewFake(time.Time{})
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		fake.Advance(250 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	collector := NewCollector()
+	client := &http.Client{Transport: NewMetering(http.DefaultTransport, collector).WithClock(fake)}
+
+	for range 2 {
+		if err := get(t, client, server.URL+"/feed"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	feed := collector.Snapshot()["/feed"]
+	if feed.Total != 500*time.Millisecond || feed.Max != 250*time.Millisecond || feed.Mean() != 250*time.Millisecond {
+		t.Fatalf("expected requests to take 250ms each, got %+v", feed)
+	}
+}
@@ -0,0 +1,37 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const pingTimeout = 3 * time.Second
+
+// Ping checks that server on endpoint is reachable by making HEAD request to its root.
+// Any response below 500 counts as success, since API servers don't have to serve their root.
+// Ping uses its own short timeout, independent of the one configured for sdk.Client.
+// Request is sent with next, which should share connections, proxy and headers with sdk.Client.
+func Ping(ctx context.Context, endpoint string, next http.RoundTripper) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("transport: failed to create ping request: %w", err)
+	}
+
+	client := &http.Client{Transport: next}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("transport: server is unreachable: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("transport: server responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	var method, key string
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, key = r.Method, r.Header.Get("X-Api-Key")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	header := http.Header{}
+	header.Set("X-Api-Key", "secret")
+	next := NewHeaders(http.DefaultTransport, header)
+
+	// API servers don't have to serve their root
+	if err := Ping(context.Background(), server.URL, next); err != nil {
+		t.Fatal(err)
+	}
+
+	if method != http.MethodHead || key != "secret" {
+		t.Fatalf("expected HEAD with X-Api-Key through provided transport, got %s with %q", method, key)
+	}
+
+	status = http.StatusBadGateway
+	if err := Ping(context.Background(), server.URL, next); err == nil {
+		t.Fatal("expected error of 502 response")
+	}
+}
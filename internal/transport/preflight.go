@@ -0,0 +1,38 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrInvalidAuthorization is returned by Preflight for requests authorized with empty token or zero user ID.
+var ErrInvalidAuthorization = errors.New("transport: authorization is incomplete, log in again")
+
+// Preflight is an http.RoundTripper which rejects requests carrying incomplete credentials
+// before they reach the server, which would only answer with a confusing 401.
+// Requests without X-Token header aren't authorized at all and pass through.
+type Preflight struct {
+	next http.RoundTripper
+}
+
+// NewPreflight creates new Preflight which wraps next http.RoundTripper.
+func NewPreflight(next http.RoundTripper) *Preflight {
+	return &Preflight{
+		next: next,
+	}
+}
+
+func (p *Preflight) RoundTrip(req *http.Request) (*http.Response, error) {
+	// sdk.Client sets both headers whenever Authorization is passed, even a zero one
+	if _, ok := req.Header["X-Token"]; ok {
+		if req.Header.Get("X-Token") == "" || req.Header.Get("X-User-Id") == "0" {
+			if req.Body != nil {
+				_ = req.Body.Close()
+			}
+
+			return nil, ErrInvalidAuthorization
+		}
+	}
+
+	return p.next.RoundTrip(req)
+}
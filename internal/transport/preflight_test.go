@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sdk "github.com/friendly-social/golang-sdk"
+)
+
+func TestPreflight(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"id":1,"accessHash":"hash","nickname":"nick","description":"","interests":[]}`))
+	}))
+	defer server.Close()
+
+	client := sdk.NewClient().
+		WithHTTPClient(&http.Client{Transport: NewPreflight(http.DefaultTransport)}).
+		WithBaseURL(server.URL)
+
+	_, err := client.GetSelfDetails(context.Background(), &sdk.Authorization{})
+	if !errors.Is(err, ErrInvalidAuthorization) {
+		t.Fatalf("expected ErrInvalidAuthorization, got %v", err)
+	}
+
+	if calls != 0 {
+		t.Fatalf("expected request not to reach server, got %d calls", calls)
+	}
+
+	token, err := sdk.NewToken(strings.Repeat("t", 256))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = client.GetSelfDetails(context.Background(), &sdk.Authorization{Id: sdk.NewUserId(1), Token: token})
+	if calls != 1 {
+		t.Fatalf("expected complete authorization to reach server, got %d calls", calls)
+	}
+}
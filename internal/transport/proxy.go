@@ -0,0 +1,32 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Proxy returns a function for http.Transport.Proxy which sends every request through proxy at raw URL.
+// Empty raw falls back to http.ProxyFromEnvironment, so HTTP_PROXY, HTTPS_PROXY and NO_PROXY are honored.
+func Proxy(raw string) (func(*http.Request) (*url.URL, error), error) {
+	if raw == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxy, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("transport: failed to parse proxy URL: %w", err)
+	}
+
+	switch proxy.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("transport: proxy URL must have http, https or socks5 scheme, got %q", raw)
+	}
+
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("transport: proxy URL has no host, got %q", raw)
+	}
+
+	return http.ProxyURL(proxy), nil
+}
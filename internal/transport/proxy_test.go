@@ -0,0 +1,47 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	var target string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// proxied requests carry absolute URL of the target
+		target = r.URL.String()
+		_, _ = io.WriteString(w, "proxied")
+	}))
+	defer proxy.Close()
+
+	proxyFunc, err := Proxy(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{Transport: &http.Transport{Proxy: proxyFunc}}
+	resp, err := client.Get("http://friendly.invalid/users/details")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != "proxied" || target != "http://friendly.invalid/users/details" {
+		t.Fatalf("expected request to go through proxy, got body %q and target %q", body, target)
+	}
+}
+
+func TestProxy_Invalid(t *testing.T) {
+	for _, raw := range []string{"://", "ftp://proxy:21", "http://"} {
+		if _, err := Proxy(raw); err == nil {
+			t.Errorf("expected %q to be rejected", raw)
+		}
+	}
+}
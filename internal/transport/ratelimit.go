@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitError is returned when server responds with 429 Too Many Requests.
+// RetryAfter holds delay from Retry-After header or 0 if server didn't provide it.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter == 0 {
+		return "rate limited by server"
+	}
+
+	return fmt.Sprintf("rate limited by server, retry after %s", e.RetryAfter)
+}
+
+// RateLimit is an http.RoundTripper which spaces requests out using token bucket limiter
+// and turns 429 Too Many Requests responses into RateLimitError.
+type RateLimit struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// NewRateLimit creates new RateLimit which allows rps requests per second with bursts up to burst requests.
+// Non-positive rps disables spacing, but 429 responses are still reported as RateLimitError.
+func NewRateLimit(next http.RoundTripper, rps float64, burst int) *RateLimit {
+	result := &RateLimit{
+		next: next,
+	}
+
+	if rps > 0 {
+		result.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+
+	return result
+}
+
+func (l *RateLimit) RoundTrip(req *http.Request) (*http.Response, error) {
+	if l.limiter != nil {
+		err := l.limiter.Wait(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("transport: failed to wait for rate limiter: %w", err)
+		}
+	}
+
+	resp, err := l.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		_ = resp.Body.Close()
+		return nil, &RateLimitError{RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	return resp, nil
+}
+
+// retryAfter parses value of Retry-After header, which is either delay in seconds or HTTP date.
+// Negative delay and date in the past mean retrying right away.
+func retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
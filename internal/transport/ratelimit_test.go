@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, client *http.Client, url string) error {
+	t.Helper()
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func TestRateLimit_Spacing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimit(http.DefaultTransport, 20, 1)}
+
+	start := time.Now()
+	for range 3 {
+		if err := get(t, client, server.URL); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// first request uses the burst, the other two wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected requests to be spaced out, took %s", elapsed)
+	}
+}
+
+func TestRateLimit_TooManyRequests(t *testing.T) {
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	cases := []struct {
+		name   string
+		header string
+		min    time.Duration
+		max    time.Duration
+	}{
+		{name: "absent", header: "", min: 0, max: 0},
+		{name: "seconds", header: "7", min: 7 * time.Second, max: 7 * time.Second},
+		{name: "negative seconds", header: "-7", min: 0, max: 0},
+		{name: "date", header: date, min: 59 * time.Minute, max: time.Hour},
+		{name: "invalid", header: "soon", min: 0, max: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if c.header != "" {
+					w.Header().Set("Retry-After", c.header)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			defer server.Close()
+
+			// limiting is disabled, but 429 must still be typed
+			client := &http.Client{Transport: NewRateLimit(http.DefaultTransport, 0, 0)}
+
+			var rateLimitErr *RateLimitError
+			err := get(t, client, server.URL)
+			if !errors.As(err, &rateLimitErr) {
+				t.Fatalf("expected RateLimitError, got %v", err)
+			}
+
+			if rateLimitErr.RetryAfter < c.min || rateLimitErr.RetryAfter > c.max {
+				t.Fatalf("expected retry after in [%s, %s], got %s", c.min, c.max, rateLimitErr.RetryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const maxRedirects = 10
+
+// RedirectError signalizes that server redirected request to another origin, which isn't followed.
+type RedirectError struct {
+	Location string
+}
+
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("transport: server redirected to %s, use it as endpoint instead", e.Location)
+}
+
+// CheckRedirect is a function for http.Client.CheckRedirect which follows only same-origin redirects.
+//
+// Go copies headers like X-Token to every redirect target, so following redirects to another host
+// could leak credentials. Such redirects, including switching from http to https, are surfaced as RedirectError,
+// so endpoint can be corrected instead. Note that on 301, 302 and 303 Go turns POST into GET and drops the body
+// even for same-origin redirects, only 307 and 308 keep the method.
+func CheckRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("transport: stopped after %d redirects", maxRedirects)
+	}
+
+	origin := via[0].URL
+	if req.URL.Scheme != origin.Scheme || req.URL.Host != origin.Host {
+		return &RedirectError{Location: req.URL.String()}
+	}
+
+	return nil
+}
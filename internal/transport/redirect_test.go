@@ -0,0 +1,77 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckRedirect(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("redirect to another origin must not be followed")
+	}))
+	defer other.Close()
+
+	var token, method string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/same":
+			http.Redirect(w, r, "/target", http.StatusMovedPermanently)
+		case "/temporary":
+			http.Redirect(w, r, "/target", http.StatusTemporaryRedirect)
+		case "/other":
+			http.Redirect(w, r, other.URL+"/target", http.StatusMovedPermanently)
+		case "/target":
+			token, method = r.Header.Get("X-Token"), r.Method
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{CheckRedirect: CheckRedirect}
+	request := func(method, path string) error {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("X-Token", "token")
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		return resp.Body.Close()
+	}
+
+	if err := request(http.MethodGet, "/same"); err != nil {
+		t.Fatal(err)
+	}
+
+	if token != "token" {
+		t.Fatalf("expected X-Token to survive same-origin redirect, got %q", token)
+	}
+
+	// 301 turns POST into GET even within the same origin, 307 keeps it
+	if err := request(http.MethodPost, "/same"); err != nil {
+		t.Fatal(err)
+	}
+
+	if method != http.MethodGet {
+		t.Fatalf("expected same-origin 301 to turn POST into GET, got %s", method)
+	}
+
+	if err := request(http.MethodPost, "/temporary"); err != nil {
+		t.Fatal(err)
+	}
+
+	if method != http.MethodPost {
+		t.Fatalf("expected same-origin 307 to keep POST, got %s", method)
+	}
+
+	var redirectErr *RedirectError
+	if err := request(http.MethodGet, "/other"); !errors.As(err, &redirectErr) {
+		t.Fatalf("expected RedirectError, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"syscall"
+)
+
+// Retry is an http.RoundTripper which retries idempotent requests exactly once
+// when a stale keep-alive connection fails with EOF or connection reset.
+type Retry struct {
+	next http.RoundTripper
+}
+
+// NewRetry creates new Retry which wraps next http.RoundTripper.
+func NewRetry(next http.RoundTripper) *Retry {
+	return &Retry{
+		next: next,
+	}
+}
+
+// stale reports whether err is caused by server closing idle connection.
+func stale(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+func (r *Retry) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err == nil || !stale(err) || req.Body != nil || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return resp, err
+	}
+
+	return r.next.RoundTrip(req)
+}
@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// flaky fails the first request with provided error, then delegates to http.DefaultTransport.
+type flaky struct {
+	err   error
+	calls int
+}
+
+func (f *flaky) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.calls++
+	if f.calls == 1 {
+		return nil, f.err
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	cases := []struct {
+		name   string
+		method string
+		body   io.Reader
+		err    error
+		calls  int
+	}{
+		{name: "get eof", method: http.MethodGet, err: io.EOF, calls: 2},
+		{name: "get other error", method: http.MethodGet, err: errors.New("boom"), calls: 1},
+		{name: "post eof", method: http.MethodPost, body: strings.NewReader("{}"), err: io.EOF, calls: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			next := &flaky{err: c.err}
+			req, err := http.NewRequest(c.method, server.URL, c.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, _ := NewRetry(next).RoundTrip(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			if next.calls != c.calls {
+				t.Fatalf("expected %d calls, got %d", c.calls, next.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package transport
+
+import "strings"
+
+// parameterized are prefixes of paths ending with user or file ID followed by its access hash.
+var parameterized = [][2]string{
+	{"users", "details"},
+	{"files", "download"},
+}
+
+// Route returns template of request path, replacing ID and access hash with placeholders,
+// so logs and metrics neither leak access hashes nor get an entry per user.
+// Paths under a base path of the endpoint keep it.
+func Route(path string) string {
+	segments := strings.Split(path, "/")
+	n := len(segments)
+	if n < 4 {
+		return path
+	}
+
+	for _, prefix := range parameterized {
+		if segments[n-4] == prefix[0] && segments[n-3] == prefix[1] {
+			return strings.Join(append(segments[:n-2:n-2], "{id}", "{hash}"), "/")
+		}
+	}
+
+	return path
+}
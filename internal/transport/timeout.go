@@ -0,0 +1,59 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Timeout is an http.RoundTripper which limits requests by a default timeout.
+//
+// Deadline already present in the request context always takes precedence, so callers can both
+// fail fast and wait longer than the default. Streaming uploads, whose length isn't known in advance,
+// aren't limited by default at all, since their duration depends on size of the file.
+type Timeout struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+// NewTimeout creates new Timeout which limits requests passed to next http.RoundTripper.
+// Zero timeout disables the default.
+func NewTimeout(next http.RoundTripper, timeout time.Duration) *Timeout {
+	return &Timeout{
+		next:    next,
+		timeout: timeout,
+	}
+}
+
+// cancelBody cancels request context once the response body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// streaming reports whether req has a body of unknown length, which http.Request marks with zero or negative ContentLength.
+func streaming(req *http.Request) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength <= 0
+}
+
+func (t *Timeout) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok || t.timeout <= 0 || streaming(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
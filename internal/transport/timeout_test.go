@@ -0,0 +1,66 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		name     string
+		deadline time.Duration
+		stream   bool
+		expired  bool
+	}{
+		{name: "default", expired: true},
+		{name: "longer deadline", deadline: time.Second, expired: false},
+		{name: "streaming upload", stream: true, expired: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.deadline != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.deadline)
+				defer cancel()
+			}
+
+			method, body := http.MethodGet, io.Reader(nil)
+			if c.stream {
+				reader, writer := io.Pipe()
+				go func() { _ = writer.Close() }()
+				method, body = http.MethodPost, reader
+			}
+
+			req, err := http.NewRequestWithContext(ctx, method, server.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := &http.Client{Transport: NewTimeout(http.DefaultTransport, 50*time.Millisecond)}
+			resp, err := client.Do(req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+
+			if expired := errors.Is(err, context.DeadlineExceeded); expired != c.expired {
+				t.Fatalf("expected expired %t, got error %v", c.expired, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrServerUnavailable is wrapped by UnavailableError, so callers can check for it with errors.Is.
+var ErrServerUnavailable = errors.New("Friendly is temporarily unavailable, try again shortly")
+
+// UnavailableError is returned when server or a gateway in front of it responds with 502, 503 or 504,
+// which usually means maintenance rather than a bug. RetryAfter holds delay from Retry-After header or 0.
+type UnavailableError struct {
+	Status     int
+	RetryAfter time.Duration
+}
+
+func (e *UnavailableError) Error() string {
+	if e.RetryAfter == 0 {
+		return fmt.Sprintf("%s (status %d)", ErrServerUnavailable, e.Status)
+	}
+
+	return fmt.Sprintf("%s (status %d, retry after %s)", ErrServerUnavailable, e.Status, e.RetryAfter)
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return ErrServerUnavailable
+}
+
+// Unavailable is an http.RoundTripper which turns 502 Bad Gateway, 503 Service Unavailable
+// and 504 Gateway Timeout responses into UnavailableError.
+type Unavailable struct {
+	next http.RoundTripper
+}
+
+// NewUnavailable creates new Unavailable which wraps next http.RoundTripper.
+func NewUnavailable(next http.RoundTripper) *Unavailable {
+	return &Unavailable{
+		next: next,
+	}
+}
+
+func (u *Unavailable) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := u.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		_ = resp.Body.Close()
+		return nil, &UnavailableError{Status: resp.StatusCode, RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	return resp, nil
+}
+
+// RetryAfter returns delay the server asked to wait before retrying request which failed with err,
+// or 0 if it didn't ask for any.
+func RetryAfter(err error) time.Duration {
+	var rateLimit *RateLimitError
+	if errors.As(err, &rateLimit) {
+		return rateLimit.RetryAfter
+	}
+
+	var unavailable *UnavailableError
+	if errors.As(err, &unavailable) {
+		return unavailable.RetryAfter
+	}
+
+	return 0
+}
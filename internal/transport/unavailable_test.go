@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnavailable(t *testing.T) {
+	cases := []struct {
+		status     int
+		header     string
+		retryAfter time.Duration
+	}{
+		{status: http.StatusBadGateway},
+		{status: http.StatusServiceUnavailable, header: "120", retryAfter: 2 * time.Minute},
+		{status: http.StatusGatewayTimeout},
+	}
+
+	for _, c := range cases {
+		t.Run(http.StatusText(c.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if c.header != "" {
+					w.Header().Set("Retry-After", c.header)
+				}
+
+				w.WriteHeader(c.status)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: NewUnavailable(http.DefaultTransport)}
+			err := get(t, client, server.URL)
+
+			var unavailable *UnavailableError
+			if !errors.As(err, &unavailable) || unavailable.Status != c.status {
+				t.Fatalf("expected UnavailableError with status %d, got %v", c.status, err)
+			}
+
+			// screens wrap errors, which must still be recognized
+			wrapped := fmt.Errorf("feed: failed to get feed queue: %w", err)
+			if !errors.Is(wrapped, ErrServerUnavailable) || RetryAfter(wrapped) != c.retryAfter {
+				t.Fatalf("expected %v with retry after %s, got %v", ErrServerUnavailable, c.retryAfter, RetryAfter(wrapped))
+			}
+		})
+	}
+}
+
+func TestUnavailable_PassesOtherStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewUnavailable(http.DefaultTransport)}
+	if err := get(t, client, server.URL); err != nil {
+		t.Fatalf("expected response to pass through, got %v", err)
+	}
+}
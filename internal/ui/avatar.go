@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+)
+
+const avatarPhoto = "📷"
+
+// Avatar returns short badge which tells users apart: camera if user has avatar, initials of nickname otherwise.
+func Avatar(nickname string, hasAvatar bool) string {
+	if hasAvatar {
+		return avatarPhoto
+	}
+
+	initials := make([]rune, 0, 2)
+	for word := range strings.FieldsFuncSeq(nickname, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		initials = append(initials, unicode.ToUpper([]rune(word)[0]))
+		if len(initials) == cap(initials) {
+			break
+		}
+	}
+
+	if len(initials) == 0 {
+		return "[?]"
+	}
+
+	return "[" + string(initials) + "]"
+}
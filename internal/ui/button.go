@@ -5,9 +5,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-var buttonSelectedStyle = lipgloss.NewStyle().Background(lipgloss.Color("#7F00FF"))
-var buttonUnselectedStyle = lipgloss.NewStyle().Background(lipgloss.Color("#808080"))
-
 // Button is an implementation of button, with which you can interact, and which can be either selected or not.
 type Button struct {
 	selected bool
@@ -24,18 +21,32 @@ func NewButton(title string, action tea.Cmd) *Button {
 	}
 }
 
+// SetTitle replaces title of the Button.
+func (b *Button) SetTitle(title string) {
+	b.title = title
+}
+
+func (b *Button) Help() string {
+	return "enter press " + b.title
+}
+
 func (b *Button) Init() tea.Cmd {
 	return nil
 }
 
 func (b *Button) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case SelectMsg:
 		b.selected = true
 	case UnselectMsg:
 		b.selected = false
 	case InteractMsg:
 		return b, b.action
+	case tea.KeyMsg:
+		// raw enter reaches Button only in insert mode, normal mode turns it into InteractMsg
+		if b.selected && msg.String() == "enter" {
+			return b, b.action
+		}
 	}
 
 	return b, nil
@@ -43,8 +54,8 @@ func (b *Button) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (b *Button) View() string {
 	if b.selected {
-		return buttonSelectedStyle.Render(b.title)
+		return lipgloss.NewStyle().Background(theme.Selected).Foreground(theme.SelectedText).Render(b.title)
 	}
 
-	return buttonUnselectedStyle.Render(b.title)
+	return lipgloss.NewStyle().Background(theme.Unselected).Render(b.title)
 }
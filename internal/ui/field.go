@@ -1,14 +1,19 @@
 package ui
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Field is an abstraction over textinput.Model for embedding it into ui package contract.
 type Field struct {
-	input *textinput.Model
+	input   *textinput.Model
+	counter bool
+	help    string
 }
 
 // NewField creates new Field based on provided textinput.Model.
@@ -19,12 +24,32 @@ func NewField(input textinput.Model) *Field {
 	}
 }
 
+// WithCounter makes Field render used/limit counter beneath input, which turns red close to the limit.
+func (f *Field) WithCounter() *Field {
+	f.counter = true
+	return f
+}
+
+// WithHelp adds hint about expected format to the keys shown while Field is focused.
+func (f *Field) WithHelp(help string) *Field {
+	f.help = help
+	return f
+}
+
+func (f *Field) Help() string {
+	if f.help == "" {
+		return "enter next field"
+	}
+
+	return f.help + " • enter next field"
+}
+
 func (f *Field) Init() tea.Cmd {
 	return nil
 }
 
 func (f *Field) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case FocusMsg:
 		return f, tea.Batch(
 			f.input.Focus(),
@@ -33,6 +58,10 @@ func (f *Field) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case UnfocusMsg:
 		f.input.Blur()
 		return f, f.input.Cursor.SetMode(cursor.CursorStatic)
+	case tea.KeyMsg:
+		if f.input.Focused() && msg.String() == "enter" {
+			return f, Next()
+		}
 	}
 
 	model, cmd := f.input.Update(msg)
@@ -41,7 +70,17 @@ func (f *Field) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (f *Field) View() string {
-	return f.input.View()
+	if !f.counter || f.input.CharLimit == 0 {
+		return f.input.View()
+	}
+
+	used := len(f.input.Value())
+	counter := fmt.Sprintf("%d/%d", used, f.input.CharLimit)
+	if used*10 >= f.input.CharLimit*9 {
+		counter = ErrorStyle().Render(counter)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, f.input.View(), counter)
 }
 
 // Value returns current filled string.
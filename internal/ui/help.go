@@ -0,0 +1,47 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Helper is implemented by components which describe keys available while they are focused.
+type Helper interface {
+	// Help returns short description of the keys, like "enter next field".
+	Help() string
+}
+
+func msg(value tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return value
+	}
+}
+
+// Next moves focus from the current component to the one below it.
+func Next() tea.Cmd {
+	return tea.Sequence(msg(UnfocusMsg{}), msg(MoveMsg{Direction: DirectionDown}), msg(FocusMsg{}))
+}
+
+// Previous moves focus from the current component to the one above it.
+func Previous() tea.Cmd {
+	return tea.Sequence(msg(UnfocusMsg{}), msg(MoveMsg{Direction: DirectionUp}), msg(FocusMsg{}))
+}
+
+// Binding describes action triggered by a key.
+type Binding struct {
+	Key    string
+	Action string
+}
+
+// Section groups bindings which apply to one part of the app.
+type Section struct {
+	Title    string
+	Bindings []Binding
+}
+
+// Binder is implemented by screens which have shortcuts of their own.
+type Binder interface {
+	Bindings() []Binding
+}
+
+// Sheet is implemented by models which know shortcuts of the whole app, listed by the cheat sheet.
+type Sheet interface {
+	Sheet() []Section
+}
@@ -1,11 +1,16 @@
 package ui
 
 import (
+	"slices"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-var listUnselectedStyle = lipgloss.NewStyle().PaddingLeft(3)
+// ListIndent is width of the cursor column, which items of List are rendered after.
+const ListIndent = 3
+
+var listUnselectedStyle = lipgloss.NewStyle().PaddingLeft(ListIndent)
 
 // List represents collection of elements that you can select and interact with.
 type List struct {
@@ -20,6 +25,75 @@ func NewList(items ...tea.Model) *List {
 	}
 }
 
+// Set replaces items of the List and selects the first one.
+func (l *List) Set(items ...tea.Model) tea.Cmd {
+	cmds := make([]tea.Cmd, 2)
+	l.items[l.cursor], cmds[0] = l.items[l.cursor].Update(UnselectMsg{})
+
+	l.items = items
+	l.cursor = 0
+
+	l.items[l.cursor], cmds[1] = l.items[l.cursor].Update(SelectMsg{})
+	return tea.Batch(cmds...)
+}
+
+// Replace replaces items of the List, keeping cursor at the same position if there are enough items.
+func (l *List) Replace(items ...tea.Model) tea.Cmd {
+	cursor := l.cursor
+	cmd := l.Set(items...)
+	return tea.Batch(cmd, l.Select(min(cursor, len(l.items)-1)))
+}
+
+// Remove deletes item with provided index, keeping cursor on the same item or on the nearest one if it was deleted.
+func (l *List) Remove(index int) tea.Cmd {
+	l.items = slices.Delete(l.items, index, index+1)
+	if l.cursor < index {
+		return nil
+	}
+
+	if l.cursor > index {
+		l.cursor--
+		return nil
+	}
+
+	l.cursor = min(l.cursor, len(l.items)-1)
+
+	var cmd tea.Cmd
+	l.items[l.cursor], cmd = l.items[l.cursor].Update(SelectMsg{})
+	return cmd
+}
+
+// Cursor returns index of the selected item.
+func (l *List) Cursor() int {
+	return l.cursor
+}
+
+// Select moves cursor to item with provided index without interacting with it.
+func (l *List) Select(index int) tea.Cmd {
+	cmds := make([]tea.Cmd, 2)
+	l.items[l.cursor], cmds[0] = l.items[l.cursor].Update(UnselectMsg{})
+	l.cursor = index
+	l.items[l.cursor], cmds[1] = l.items[l.cursor].Update(SelectMsg{})
+	return tea.Batch(cmds...)
+}
+
+// Activate moves cursor to item with provided index and interacts with it.
+func (l *List) Activate(index int) tea.Cmd {
+	cmds := make([]tea.Cmd, 2)
+	cmds[0] = l.Select(index)
+	l.items[l.cursor], cmds[1] = l.items[l.cursor].Update(InteractMsg{})
+	return tea.Batch(cmds...)
+}
+
+// Help returns keys of the selected item or empty string if it doesn't describe them.
+func (l *List) Help() string {
+	if helper, ok := l.items[l.cursor].(Helper); ok {
+		return helper.Help()
+	}
+
+	return ""
+}
+
 func (l *List) Init() tea.Cmd {
 	return nil
 }
@@ -40,6 +114,17 @@ func (l *List) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		l.items[l.cursor], cmds[1] = l.items[l.cursor].Update(SelectMsg{})
 		return l, tea.Batch(cmds...)
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return l, nil
+		}
+
+		index := l.at(msg.Y)
+		if index < 0 {
+			return l, nil
+		}
+
+		return l, l.Activate(index)
 	}
 
 	var cmd tea.Cmd
@@ -47,6 +132,24 @@ func (l *List) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return l, cmd
 }
 
+// at returns index of item rendered on line y or -1 if there's no such item.
+func (l *List) at(y int) int {
+	if y < 0 {
+		return -1
+	}
+
+	for i, item := range l.items {
+		height := lipgloss.Height(item.View())
+		if y < height {
+			return i
+		}
+
+		y -= height
+	}
+
+	return -1
+}
+
 func (l *List) View() string {
 	views := make([]string, len(l.items))
 	for i, input := range l.items {
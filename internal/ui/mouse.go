@@ -0,0 +1,15 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ShiftMouse makes mouse event's coordinates relative to the component rendered right below provided views.
+func ShiftMouse(msg tea.MouseMsg, above ...string) tea.MouseMsg {
+	for _, view := range above {
+		msg.Y -= lipgloss.Height(view)
+	}
+
+	return msg
+}
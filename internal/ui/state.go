@@ -0,0 +1,16 @@
+package ui
+
+// ListState renders status line of a list view which is loading, failed or empty, in that order of precedence.
+// Returns false if the list is in none of these states, so the caller renders its own status.
+func ListState(items int, err error, loading bool, empty string) (string, bool) {
+	switch {
+	case loading:
+		return "loading...", false
+	case err != nil:
+		return ErrorStyle().Render(err.Error() + " (r to retry)"), false
+	case items == 0:
+		return empty, false
+	}
+
+	return "", true
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Parser splits text into tags, returning accepted ones and an error for every rejected one.
+type Parser func(text string) ([]string, []error)
+
+// Tags is a tag editor which turns entered text into chips on enter or comma.
+// Pasted text is parsed at once, so it can hold several tags. Backspace on empty input removes the last chip.
+type Tags struct {
+	input *textinput.Model
+	parse Parser
+
+	tags []string
+	errs []error
+}
+
+// NewTags creates new Tags based on provided textinput.Model. Entered text is turned into tags by parse.
+func NewTags(input textinput.Model, parse Parser) *Tags {
+	input.Blur()
+	return &Tags{
+		input: &input,
+		parse: parse,
+	}
+}
+
+func (t *Tags) Init() tea.Cmd {
+	return nil
+}
+
+// add turns text into tags, skipping ones which are already added, ignoring case.
+// Input is kept if nothing was accepted, so a single rejected tag can be fixed.
+func (t *Tags) add(text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	var tags []string
+	tags, t.errs = t.parse(text)
+	for _, tag := range tags {
+		if !slices.ContainsFunc(t.tags, func(existing string) bool { return strings.EqualFold(existing, tag) }) {
+			t.tags = append(t.tags, tag)
+		}
+	}
+
+	if len(tags) != 0 || len(t.errs) == 0 {
+		t.input.SetValue("")
+		return
+	}
+
+	t.input.SetValue(text)
+}
+
+func (t *Tags) Help() string {
+	return "enter or , add • backspace remove last"
+}
+
+func (t *Tags) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FocusMsg:
+		return t, tea.Batch(
+			t.input.Focus(),
+			t.input.Cursor.SetMode(cursor.CursorBlink),
+		)
+	case UnfocusMsg:
+		// text left in the input would be lost silently otherwise
+		t.add(t.input.Value())
+		t.input.Blur()
+		return t, t.input.Cursor.SetMode(cursor.CursorStatic)
+	case tea.KeyMsg:
+		if !t.input.Focused() {
+			break
+		}
+
+		// input would turn pasted newlines into spaces, losing them as delimiters
+		if msg.Paste {
+			t.add(t.input.Value() + string(msg.Runes))
+			return t, nil
+		}
+
+		switch msg.String() {
+		case "enter", ",":
+			t.add(t.input.Value())
+			return t, nil
+		case "backspace":
+			if t.input.Value() == "" && len(t.tags) != 0 {
+				t.tags = t.tags[:len(t.tags)-1]
+				t.errs = nil
+				return t, nil
+			}
+		}
+	}
+
+	model, cmd := t.input.Update(msg)
+	*t.input = model
+	return t, cmd
+}
+
+func (t *Tags) View() string {
+	chip := lipgloss.NewStyle().Background(theme.Unselected).Padding(0, 1)
+	chips := make([]string, 0, 2*len(t.tags)+1)
+	for _, tag := range t.tags {
+		chips = append(chips, chip.Render(tag), " ")
+	}
+
+	lines := []string{lipgloss.JoinHorizontal(lipgloss.Top, append(chips, t.input.View())...)}
+	for _, err := range t.errs {
+		lines = append(lines, ErrorStyle().Render(err.Error()))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// Value returns added tags.
+func (t *Tags) Value() []string {
+	return t.tags
+}
+
+// Raw returns underlying textinput.Model.
+func (t *Tags) Raw() *textinput.Model {
+	return t.input
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const ellipsis = "..."
+
+// SummaryLines limits height of list items summarizing a user, so long descriptions don't push the rest of the list away.
+const SummaryLines = 2
+
+// Shorten collapses whitespace of text, including newlines, and cuts it to width cells, marking the cut with ellipsis.
+func Shorten(text string, width int) string {
+	return ansi.Truncate(strings.Join(strings.Fields(text), " "), max(width, 0), ellipsis)
+}
+
+// Wrap breaks text into lines of width cells, preferring to break between words. Non-positive width keeps text as is.
+func Wrap(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+
+	return ansi.Wrap(text, width, "")
+}
+
+// Clamp fits line made of prefix, text and suffix into at most lines lines of width cells, shortening text if needed.
+// Lines break between words, so text is shortened further when breaking leaves the last line no room for the rest.
+// Non-positive width, which screens have before the first tea.WindowSizeMsg, keeps the line as is.
+func Clamp(prefix, text, suffix string, width, lines int) string {
+	if width <= 0 {
+		return prefix + text + suffix
+	}
+
+	budget := width*lines - ansi.StringWidth(prefix) - ansi.StringWidth(suffix)
+	for {
+		wrapped := Wrap(prefix+Shorten(text, budget)+suffix, width)
+		rows := strings.Split(wrapped, "\n")
+		if len(rows) <= lines || budget <= 0 {
+			return wrapped
+		}
+
+		// shorten text at least by the width of rows which don't fit
+		budget -= max(ansi.StringWidth(strings.Join(rows[lines:], "")), 1)
+	}
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds colors of all styles in the application. Adaptive colors follow terminal background,
+// and NO_COLOR disables colors altogether.
+type Theme struct {
+	Selected     lipgloss.TerminalColor
+	SelectedText lipgloss.TerminalColor
+	Unselected   lipgloss.TerminalColor
+	Error        lipgloss.TerminalColor
+	Success      lipgloss.TerminalColor
+}
+
+var themes = map[string]Theme{
+	"default": {
+		Selected:     lipgloss.Color("#7F00FF"),
+		SelectedText: lipgloss.NoColor{},
+		Unselected:   lipgloss.Color("#808080"),
+		Error:        lipgloss.AdaptiveColor{Light: "#C00000", Dark: "#FF0000"},
+		Success:      lipgloss.AdaptiveColor{Light: "#008000", Dark: "#00FF00"},
+	},
+	"high-contrast": {
+		Selected:     lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		SelectedText: lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+		Unselected:   lipgloss.NoColor{},
+		Error:        lipgloss.AdaptiveColor{Light: "#A00000", Dark: "#FF5555"},
+		Success:      lipgloss.AdaptiveColor{Light: "#005F00", Dark: "#55FF55"},
+	},
+}
+
+var theme = themes["default"]
+
+// Themes returns names of built-in themes.
+func Themes() []string {
+	return slices.Sorted(maps.Keys(themes))
+}
+
+// SetTheme switches all styles to built-in theme with provided name.
+func SetTheme(name string) error {
+	value, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("ui: unknown theme %q, choose one of: %s", name, strings.Join(Themes(), ", "))
+	}
+
+	theme = value
+	return nil
+}
+
+// ErrorStyle returns style of error messages in the current theme.
+func ErrorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Error)
+}
+
+// SuccessStyle returns style of success messages in the current theme.
+func SuccessStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Success)
+}